@@ -3,9 +3,21 @@ package ast
 
 // All node types implement the Node interface.
 type Node interface {
+	// Pos returns the position of the first character belonging to the
+	// node, excluding the expression X which the node follows.
 	Pos() int
 }
 
+// An EndNode is a Node which knows its end position. All node types of this
+// package implement it; it is separate from Node so that the implementations
+// of Node outside this package need not implement End.
+type EndNode interface {
+	Node
+	// End returns the position of the first character immediately after
+	// the node.
+	End() int
+}
+
 type (
 	// A Root node represents a root expression.
 	Root struct {
@@ -15,6 +27,10 @@ type (
 	// A Selector node represents an expression followed by a selector.
 	Selector struct {
 		ValuePos int
+		// ValueEnd is the position immediately after the selector, e.g.
+		// after "]" of "['key']". It is set by the parser; if it is zero,
+		// End computes the position from the canonical source.
+		ValueEnd int
 		X        Node
 		Sel      string
 	}
@@ -22,6 +38,10 @@ type (
 	// An Index node represents an expression followed by an index.
 	Index struct {
 		ValuePos int
+		// ValueEnd is the position immediately after "]". It is set by the
+		// parser; if it is zero, End computes the position from the
+		// canonical source.
+		ValueEnd int
 		X        Node
 		Index    int
 	}
@@ -31,3 +51,18 @@ type (
 func (e *Root) Pos() int     { return e.ValuePos }
 func (e *Selector) Pos() int { return e.ValuePos }
 func (e *Index) Pos() int    { return e.ValuePos }
+
+// End returns the position of the first character immediately after the node.
+func (e *Root) End() int { return e.ValuePos + 1 }
+func (e *Selector) End() int {
+	if e.ValueEnd != 0 {
+		return e.ValueEnd
+	}
+	return e.ValuePos + len([]rune(selectorString(e.Sel)))
+}
+func (e *Index) End() int {
+	if e.ValueEnd != 0 {
+		return e.ValueEnd
+	}
+	return e.ValuePos + len([]rune(indexString(e.Index)))
+}
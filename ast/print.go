@@ -0,0 +1,114 @@
+package ast
+
+import (
+	"fmt"
+	"io"
+	"strings"
+)
+
+// A PrintConfig controls the output of Fprint.
+type PrintConfig struct {
+	// Bracket writes all selectors in the bracket notation like "['key']".
+	Bracket bool
+	// DoubleQuote quotes the selectors in the bracket notation with double
+	// quotes like `["key"]`.
+	DoubleQuote bool
+}
+
+// Fprint writes the canonical source of node to w. The source is parsed into
+// the equivalent AST, and it is the same as the string representation of the
+// query built from node: a selector is written as ".key", or as "['key']" if
+// the key would be tokenized differently.
+func Fprint(w io.Writer, node Node) error {
+	return (&PrintConfig{}).Fprint(w, node)
+}
+
+// Sprint returns the canonical source of node. It returns an empty string if
+// node has an unknown node type; use Fprint to get the error.
+func Sprint(node Node) string {
+	return (&PrintConfig{}).Sprint(node)
+}
+
+// Fprint writes the source of node to w in the style of c.
+func (c *PrintConfig) Fprint(w io.Writer, node Node) error {
+	var b strings.Builder
+	if err := c.fprint(&b, node); err != nil {
+		return err
+	}
+	_, err := io.WriteString(w, b.String())
+	return err
+}
+
+// Sprint returns the source of node in the style of c. It returns an empty
+// string if node has an unknown node type.
+func (c *PrintConfig) Sprint(node Node) string {
+	var b strings.Builder
+	if err := c.fprint(&b, node); err != nil {
+		return ""
+	}
+	return b.String()
+}
+
+func (c *PrintConfig) fprint(b *strings.Builder, node Node) error {
+	switch n := node.(type) {
+	case nil:
+	case *Root:
+		b.WriteString("$")
+	case *Selector:
+		if err := c.fprint(b, n.X); err != nil {
+			return err
+		}
+		b.WriteString(c.selectorString(n.Sel))
+	case *Index:
+		if err := c.fprint(b, n.X); err != nil {
+			return err
+		}
+		b.WriteString(indexString(n.Index))
+	default:
+		return fmt.Errorf("unknown node type: %T", node)
+	}
+	return nil
+}
+
+func (c *PrintConfig) selectorString(sel string) string {
+	q := '\''
+	if c.DoubleQuote {
+		q = '"'
+	}
+	if c.Bracket {
+		return quote(sel, q)
+	}
+	if sel == "" {
+		return quote(sel, q)
+	}
+	for _, ch := range sel {
+		switch ch {
+		case '[', ']', '.', '\\', '\'', '$':
+			return quote(sel, q)
+		}
+	}
+	return "." + sel
+}
+
+func selectorString(sel string) string {
+	return (&PrintConfig{}).selectorString(sel)
+}
+
+func indexString(i int) string {
+	return fmt.Sprintf("[%d]", i)
+}
+
+func quote(s string, q rune) string {
+	var b strings.Builder
+	b.WriteRune('[')
+	b.WriteRune(q)
+	for _, ch := range s {
+		if ch == '\\' || ch == q {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(ch)
+	}
+	b.WriteRune(q)
+	b.WriteRune(']')
+	return b.String()
+}
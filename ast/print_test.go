@@ -0,0 +1,104 @@
+package ast
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestFprint(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			node      Node
+			expect    string
+			expectEnd int
+		}{
+			"nil": {
+				node:   nil,
+				expect: "",
+			},
+			"root": {
+				node:      &Root{ValuePos: 1},
+				expect:    "$",
+				expectEnd: 2,
+			},
+			"selector": {
+				node: &Selector{
+					ValuePos: 2,
+					X:        &Root{ValuePos: 1},
+					Sel:      "a",
+				},
+				expect:    "$.a",
+				expectEnd: 4,
+			},
+			"quoted selector": {
+				node: &Selector{
+					ValuePos: 1,
+					Sel:      "it's",
+				},
+				expect:    `['it\'s']`,
+				expectEnd: 10,
+			},
+			"empty selector": {
+				node: &Selector{
+					ValuePos: 1,
+					Sel:      "",
+				},
+				expect:    "['']",
+				expectEnd: 5,
+			},
+			"index": {
+				node: &Index{
+					ValuePos: 3,
+					X: &Selector{
+						ValuePos: 1,
+						Sel:      "a",
+					},
+					Index: -1,
+				},
+				expect:    ".a[-1]",
+				expectEnd: 7,
+			},
+			"end set by the parser": {
+				node: &Selector{
+					ValuePos: 1,
+					ValueEnd: 7,
+					Sel:      "a",
+				},
+				expect:    ".a",
+				expectEnd: 7,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				var b strings.Builder
+				if err := Fprint(&b, test.node); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got := b.String(); got != test.expect {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+				if got := Sprint(test.node); got != test.expect {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+				if test.node != nil {
+					if got := test.node.(EndNode).End(); got != test.expectEnd {
+						t.Errorf("expect end %d but got %d", test.expectEnd, got)
+					}
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		var b strings.Builder
+		if err := Fprint(&b, &Index{X: badNode{}}); err == nil {
+			t.Fatal("no error")
+		}
+		if got := Sprint(&Index{X: badNode{}}); got != "" {
+			t.Errorf("expect empty string but got %q", got)
+		}
+	})
+}
+
+type badNode struct{}
+
+func (badNode) Pos() int { return 0 }
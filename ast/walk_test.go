@@ -0,0 +1,50 @@
+package ast
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestInspect(t *testing.T) {
+	node := &Selector{
+		X: &Index{
+			X:     &Root{},
+			Index: 0,
+		},
+		Sel: "a",
+	}
+	tests := map[string]struct {
+		f      func(n Node) bool
+		expect []string
+	}{
+		"all": {
+			f:      func(n Node) bool { return true },
+			expect: []string{"*ast.Selector", "*ast.Index", "*ast.Root", "<nil>", "<nil>", "<nil>"},
+		},
+		"stop at index": {
+			f: func(n Node) bool {
+				_, ok := n.(*Index)
+				return !ok
+			},
+			expect: []string{"*ast.Selector", "*ast.Index", "<nil>"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got []string
+			Inspect(node, func(n Node) bool {
+				got = append(got, fmt.Sprintf("%T", n))
+				if n == nil {
+					got[len(got)-1] = "<nil>"
+					return false
+				}
+				return test.f(n)
+			})
+			if diff := cmp.Diff(test.expect, got); diff != "" {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
@@ -3,6 +3,10 @@ package query
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
 )
 
 // ErrNotFound is the sentinel error that reports the queried element as
@@ -15,6 +19,18 @@ import (
 // cancellation that interrupted a blocking extractor.
 var ErrNotFound = errors.New("not found")
 
+// ErrUnexportedField is the sentinel error that reports an extractor returned
+// a value obtained from an unexported struct field or method, which can not be
+// accessed through reflection. Query.Extract returns it wrapped in an
+// *ExtractError.
+var ErrUnexportedField = errors.New("can not access unexported field or method")
+
+// ErrTypeMismatch is the sentinel error that reports the queried value can
+// not have the queried element, e.g. a key of an int or an index of a map
+// with string keys. A *TypeMismatchError also matches ErrNotFound for
+// compatibility, so Query.Extract returns it wrapped in a *NotFoundError.
+var ErrTypeMismatch = errors.New("type mismatch")
+
 // NotFoundError is the error returned by Query.Extract when the queried
 // element is absent. It matches ErrNotFound with errors.Is and carries the
 // position information of the failure.
@@ -30,10 +46,30 @@ type NotFoundError struct {
 	// extractor ran a sub-query, the inner *NotFoundError. It is exposed
 	// via Unwrap, not via Error, so the message stays stable.
 	Err error
+	// Available is the sorted keys which were available at the failing
+	// step, if it is a key: the string keys of a map, the field names and
+	// the struct tag names of a struct, and the keys reported by a
+	// KeyExtractor implementing KeyLister. It is set only if the query
+	// has the Suggestions option.
+	Available []string
+	// Suggestions is the keys of Available which are close to the failing
+	// key by the edit distance, ordered from the closest.
+	Suggestions []string
+
+	suggestionsInMessage bool
 }
 
 // Error implements the error interface.
+// The suggestions are appended to the message ("did you mean ...?") only if
+// the query has the SuggestionsInMessage option.
 func (e *NotFoundError) Error() string {
+	if e.suggestionsInMessage && len(e.Suggestions) > 0 {
+		quoted := make([]string, len(e.Suggestions))
+		for i, s := range e.Suggestions {
+			quoted[i] = strconv.Quote(s)
+		}
+		return fmt.Sprintf(`"%s" not found; did you mean %s?`, e.Query, strings.Join(quoted, " or "))
+	}
 	return fmt.Sprintf(`"%s" not found`, e.Query)
 }
 
@@ -47,3 +83,97 @@ func (e *NotFoundError) Is(target error) bool {
 func (e *NotFoundError) Unwrap() error {
 	return e.Err
 }
+
+// TypeMismatchError is the error returned by Key.Extract and Index.Extract
+// when the value is not of the kinds which can be accessed by the extractor.
+// It matches both ErrTypeMismatch and ErrNotFound with errors.Is.
+type TypeMismatchError struct {
+	// Expected is the kinds which can be accessed by the extractor, e.g.
+	// map and struct for a key.
+	Expected []reflect.Kind
+	// Actual is the kind of the value.
+	Actual reflect.Kind
+	// Type is the type of the value.
+	Type reflect.Type
+}
+
+// Error implements the error interface.
+func (e *TypeMismatchError) Error() string {
+	expected := make([]string, len(e.Expected))
+	for i, k := range e.Expected {
+		expected[i] = k.String()
+	}
+	if n := len(expected); n > 1 {
+		expected = append(expected[:n-2], expected[n-2]+" or "+expected[n-1])
+	}
+	got := e.Actual.String()
+	if slices.Contains(e.Expected, e.Actual) && e.Type != nil {
+		// e.g. a map whose key type does not match.
+		got = e.Type.String()
+	}
+	return fmt.Sprintf("expected %s but got %s", strings.Join(expected, ", "), got)
+}
+
+// Is reports whether target is ErrTypeMismatch or ErrNotFound.
+func (e *TypeMismatchError) Is(target error) bool {
+	return target == ErrTypeMismatch || target == ErrNotFound
+}
+
+func typeMismatch(v reflect.Value, expected ...reflect.Kind) *TypeMismatchError {
+	return &TypeMismatchError{
+		Expected: expected,
+		Actual:   v.Kind(),
+		Type:     v.Type(),
+	}
+}
+
+// ExtractError is the error returned by Query.Extract when an extractor fails
+// for a reason other than the absence of the queried element, e.g. a context
+// cancellation or an access to an unexported field (ErrUnexportedField).
+type ExtractError struct {
+	// Query is the string representation of the whole query.
+	Query string
+	// FailedAt is the prefix of the query up to and including the extractor
+	// that failed.
+	FailedAt string
+	// Step is the zero-based index of the failed extractor in the query.
+	Step int
+	// ValueType is the dynamic type of the value which the failed extractor
+	// was applied to, or nil if the value was nil.
+	ValueType reflect.Type
+	// Err is the error reported by the extractor.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *ExtractError) Error() string {
+	return fmt.Sprintf("%s: %s", e.FailedAt, e.Err)
+}
+
+// Unwrap returns the extractor's original error.
+func (e *ExtractError) Unwrap() error {
+	return e.Err
+}
+
+// PanicError is the error which reports a panic raised during an extraction
+// step. Query.Extract returns it wrapped in an *ExtractError if the query has
+// the RecoverPanic option.
+type PanicError struct {
+	// Value is the value passed to panic.
+	Value any
+	// Stack is the stack trace of the goroutine at the panic.
+	Stack []byte
+}
+
+// Error implements the error interface.
+func (e *PanicError) Error() string {
+	return fmt.Sprintf("panic: %v", e.Value)
+}
+
+// Unwrap returns the panic value if it is an error, e.g. a runtime.Error.
+func (e *PanicError) Unwrap() error {
+	if err, ok := e.Value.(error); ok {
+		return err
+	}
+	return nil
+}
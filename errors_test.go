@@ -1,8 +1,10 @@
 package query
 
 import (
+	"context"
 	"errors"
 	"fmt"
+	"reflect"
 	"testing"
 )
 
@@ -36,3 +38,63 @@ func TestNotFoundError_Unwrap(t *testing.T) {
 		t.Errorf("expected the extractor's original error, got %v", got)
 	}
 }
+
+func TestExtractError(t *testing.T) {
+	err := error(&ExtractError{Query: ".a.b", FailedAt: ".a", Err: ErrUnexportedField})
+	if got, expect := err.Error(), ".a: can not access unexported field or method"; got != expect {
+		t.Errorf("expected %q but got %q", expect, got)
+	}
+	if !errors.Is(err, ErrUnexportedField) {
+		t.Error("expected errors.Is(err, ErrUnexportedField) to be true")
+	}
+	if errors.Is(err, ErrNotFound) {
+		t.Error("expected errors.Is(err, ErrNotFound) to be false")
+	}
+}
+
+func TestTypeMismatchError(t *testing.T) {
+	tests := map[string]struct {
+		err    *TypeMismatchError
+		expect string
+	}{
+		"kind": {
+			err: &TypeMismatchError{
+				Expected: []reflect.Kind{reflect.Map, reflect.Struct},
+				Actual:   reflect.String,
+				Type:     reflect.TypeOf(""),
+			},
+			expect: "expected map or struct but got string",
+		},
+		"map key": {
+			err: &TypeMismatchError{
+				Expected: []reflect.Kind{reflect.Slice, reflect.Array, reflect.Map},
+				Actual:   reflect.Map,
+				Type:     reflect.TypeOf(map[string]int{}),
+			},
+			expect: "expected slice, array or map but got map[string]int",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := test.err.Error(); got != test.expect {
+				t.Errorf("expected %q but got %q", test.expect, got)
+			}
+		})
+	}
+}
+
+func TestQuery_Extract_TypeMismatch(t *testing.T) {
+	q := New().Key("user").Key("name")
+	_, err := q.Extract(context.Background(), map[string]any{"user": "alice"})
+	var nfe *NotFoundError
+	if !errors.As(err, &nfe) {
+		t.Fatalf("expected *NotFoundError but got %v", err)
+	}
+	var tme *TypeMismatchError
+	if !errors.As(err, &tme) {
+		t.Fatalf("expected *TypeMismatchError but got %v", err)
+	}
+	if got, expect := fmt.Sprintf("%s at %s", tme, nfe.FailedAt), "expected map or struct but got string at .user.name"; got != expect {
+		t.Errorf("expected %q but got %q", expect, got)
+	}
+}
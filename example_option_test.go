@@ -76,3 +76,33 @@ func ExampleCustomStructFieldNameGetter() {
 	// Output:
 	// Alice
 }
+
+type printTracer struct{}
+
+func (printTracer) StepStart(context.Context, query.Step) {}
+
+func (printTracer) StepEnd(_ context.Context, step query.Step, res query.StepResult) {
+	fmt.Printf("%d %s on %s: %s", step.Index, step.Extractor, step.InputType, res.Resolution)
+	if res.Err != nil {
+		fmt.Printf(" (%s)", res.Err)
+	}
+	fmt.Println()
+}
+
+func ExampleWithTracer() {
+	people := map[string][]Person{
+		"members": {{Name: "Alice"}},
+	}
+
+	q := query.New(
+		query.ExtractByStructTag("json"),
+		query.WithTracer(printTracer{}),
+	).Key("members").Index(0).Key("name")
+	name, _ := q.Extract(context.Background(), people)
+	fmt.Println(name)
+	// Output:
+	// 0 .members on map[string][]query_test.Person: map key
+	// 1 [0] on []query_test.Person: sequence index
+	// 2 .name on query_test.Person: struct tag
+	// Alice
+}
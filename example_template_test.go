@@ -0,0 +1,27 @@
+package query_test
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+func ExampleCompileTemplate() {
+	tmpl, err := query.CompileTemplate("user {{ $.user.name }} has {{ $.items[-1].id }}")
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+	s, err := tmpl.Expand(context.Background(), map[string]any{
+		"user":  map[string]any{"name": "alice"},
+		"items": []map[string]int{{"id": 1}, {"id": 2}},
+	})
+	fmt.Println(s, err)
+
+	_, err = tmpl.Expand(context.Background(), map[string]any{})
+	fmt.Println(err)
+	// Output:
+	// user alice has 2 <nil>
+	// col 9: "$.user.name" not found
+}
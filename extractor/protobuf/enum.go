@@ -0,0 +1,86 @@
+package protobuf
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+var (
+	enumType   = reflect.TypeFor[protoreflect.Enum]()
+	stringType = reflect.TypeFor[string]()
+)
+
+// ParseEnum returns the value of the enum type E named name, e.g.
+// ParseEnum[pb.Status]("STATUS_ACTIVE"). A decimal number is accepted as well,
+// so that a value extracted with the EnumAsName option for a number without
+// a name can be parsed back.
+func ParseEnum[E interface {
+	~int32
+	protoreflect.Enum
+}](name string) (E, error) {
+	var e E
+	if v := e.Descriptor().Values().ByName(protoreflect.Name(name)); v != nil {
+		return E(v.Number()), nil
+	}
+	if n, err := strconv.ParseInt(name, 10, 32); err == nil {
+		return E(n), nil
+	}
+	return e, fmt.Errorf("%q is not a value of enum %s", name, e.Descriptor().FullName())
+}
+
+// enumToName converts an enum value, or a pointer, slice or map of them, to
+// the name of the value. Any other value is returned as is.
+func enumToName(v reflect.Value) reflect.Value {
+	if !v.IsValid() || !v.CanInterface() {
+		return v
+	}
+	in := v
+	if v.Kind() == reflect.Interface {
+		if v = v.Elem(); !v.IsValid() {
+			return in
+		}
+	}
+	switch v.Kind() {
+	case reflect.Int32:
+		if v.Type().Implements(enumType) {
+			return reflect.ValueOf(enumName(v.Interface().(protoreflect.Enum)))
+		}
+	case reflect.Pointer:
+		if v.Type().Elem().Kind() == reflect.Int32 && v.Type().Elem().Implements(enumType) {
+			if v.IsNil() {
+				return reflect.Value{}
+			}
+			return reflect.ValueOf(enumName(v.Elem().Interface().(protoreflect.Enum)))
+		}
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.Int32 && v.Type().Elem().Implements(enumType) {
+			names := make([]string, v.Len())
+			for i := range v.Len() {
+				names[i] = enumName(v.Index(i).Interface().(protoreflect.Enum))
+			}
+			return reflect.ValueOf(names)
+		}
+	case reflect.Map:
+		if v.Type().Elem().Kind() == reflect.Int32 && v.Type().Elem().Implements(enumType) {
+			m := reflect.MakeMapWithSize(reflect.MapOf(v.Type().Key(), stringType), v.Len())
+			iter := v.MapRange()
+			for iter.Next() {
+				m.SetMapIndex(iter.Key(), reflect.ValueOf(enumName(iter.Value().Interface().(protoreflect.Enum))))
+			}
+			return m
+		}
+	}
+	return in
+}
+
+// enumName returns the name of e, or its number as a decimal string if the
+// number has no name.
+func enumName(e protoreflect.Enum) string {
+	if v := e.Descriptor().Values().ByNumber(e.Number()); v != nil {
+		return string(v.Name())
+	}
+	return strconv.FormatInt(int64(e.Number()), 10)
+}
@@ -0,0 +1,120 @@
+package protobuf
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	testpb "github.com/zoncoen/query-go/extractor/protobuf/testdata/gen/testpb"
+	"github.com/zoncoen/query-go/v2"
+)
+
+func TestEnumAsName(t *testing.T) {
+	msg := &testpb.Message{
+		Status:   testpb.Status_STATUS_ACTIVE,
+		Statuses: []testpb.Status{testpb.Status_STATUS_INACTIVE, testpb.Status(10)},
+		StatusMap: map[string]testpb.Status{
+			"a": testpb.Status_STATUS_ACTIVE,
+		},
+	}
+	tests := map[string]struct {
+		query  *query.Query
+		expect any
+	}{
+		"enum": {
+			query:  query.New().Key("status"),
+			expect: "STATUS_ACTIVE",
+		},
+		"repeated enum": {
+			query:  query.New().Key("statuses"),
+			expect: []string{"STATUS_INACTIVE", "10"},
+		},
+		"element of repeated enum": {
+			query:  query.New().Key("statuses").Index(0),
+			expect: "STATUS_INACTIVE",
+		},
+		"unknown number": {
+			query:  query.New().Key("statuses").Index(1),
+			expect: "10",
+		},
+		"map of enum": {
+			query:  query.New().Key("status_map"),
+			expect: map[string]string{"a": "STATUS_ACTIVE"},
+		},
+		"value of map of enum": {
+			query:  query.New().Key("status_map").Key("a"),
+			expect: "STATUS_ACTIVE",
+		},
+		"not enum": {
+			query:  query.New().Key("count"),
+			expect: int32(0),
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			q := query.New(
+				query.CustomExtractFunc(ExtractFunc(EnumAsName())),
+			).Append(test.query.Extractors()...)
+			got, err := q.Extract(context.Background(), msg)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(got, test.expect) {
+				t.Errorf("expect %#v but got %#v", test.expect, got)
+			}
+		})
+	}
+	t.Run("root enum", func(t *testing.T) {
+		got := enumToName(reflect.ValueOf(testpb.Status_STATUS_INACTIVE.Enum()))
+		if got.Interface() != "STATUS_INACTIVE" {
+			t.Errorf("expect %q but got %v", "STATUS_INACTIVE", got)
+		}
+		if got := enumToName(reflect.ValueOf((*testpb.Status)(nil))); got.IsValid() {
+			t.Errorf("expect invalid value but got %v", got)
+		}
+	})
+}
+
+func TestParseEnum(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			name   string
+			expect testpb.Status
+		}{
+			"name": {
+				name:   "STATUS_ACTIVE",
+				expect: testpb.Status_STATUS_ACTIVE,
+			},
+			"number": {
+				name:   "2",
+				expect: testpb.Status_STATUS_INACTIVE,
+			},
+			"unknown number": {
+				name:   "10",
+				expect: testpb.Status(10),
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				got, err := ParseEnum[testpb.Status](test.name)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expect {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		_, err := ParseEnum[testpb.Status]("status_active")
+		if err == nil {
+			t.Fatal("no error")
+		}
+		if got, expect := err.Error(), `"status_active" is not a value of enum com.github.zoncoen.querygo.extractor.protobuf.Status`; got != expect {
+			t.Errorf("expect %q but got %q", expect, got)
+		}
+	})
+}
@@ -6,6 +6,9 @@ import (
 	"log"
 
 	"github.com/zoncoen/query-go/v2"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
 
 	protobufextractor "github.com/zoncoen/query-go/extractor/protobuf"
 	testpb "github.com/zoncoen/query-go/extractor/protobuf/testdata/gen/testpb"
@@ -31,3 +34,52 @@ func ExampleExtractFunc() {
 	// Output:
 	// yyy
 }
+
+func ExampleUnwrapWellKnownTypes() {
+	s, err := structpb.NewStruct(map[string]any{
+		"tags": []any{"a", "b"},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	v := &testpb.WellKnownTypesMessage{
+		StringValue: wrapperspb.String("xxx"),
+		Struct:      s,
+	}
+	opt := query.CustomExtractFunc(protobufextractor.ExtractFunc(protobufextractor.UnwrapWellKnownTypes()))
+	for _, q := range []*query.Query{
+		query.New(opt).Key("string_value"),
+		query.New(opt).Key("struct").Key("tags").Index(1),
+	} {
+		got, err := q.Extract(context.Background(), v)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(got)
+	}
+	// Output:
+	// xxx
+	// b
+}
+
+func ExampleWireMessage() {
+	b, err := proto.Marshal(&testpb.Message{
+		NestedList: []*testpb.Message_Nested{
+			{Value: "aaa"},
+			{Value: "bbb"},
+		},
+	})
+	if err != nil {
+		log.Fatal(err)
+	}
+	md := (&testpb.Message{}).ProtoReflect().Descriptor()
+	got, err := query.New().Key("nested_list").Index(1).Key("value").Extract(
+		context.Background(), protobufextractor.NewWireMessage(b, md),
+	)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(got)
+	// Output:
+	// bbb
+}
@@ -0,0 +1,69 @@
+package protobuf
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+// extensionName returns the full name of the extension field if key is
+// enclosed in brackets like the protobuf text format, e.g. "[pkg.ext_name]".
+func extensionName(key string) (protoreflect.FullName, bool) {
+	s, ok := strings.CutPrefix(key, "[")
+	if !ok {
+		return "", false
+	}
+	s, ok = strings.CutSuffix(s, "]")
+	if !ok {
+		return "", false
+	}
+	name := protoreflect.FullName(s)
+	return name, name.IsValid()
+}
+
+// extractExtension extracts the extension field named name of the message.
+func (e *keyExtractor) extractExtension(name protoreflect.FullName) (any, error) {
+	m, ok := e.message()
+	if !ok {
+		return nil, query.ErrNotFound
+	}
+	r := e.c.extensionTypes
+	if r == nil {
+		r = protoregistry.GlobalTypes
+	}
+	xt, err := r.FindExtensionByName(name)
+	if err != nil {
+		if errors.Is(err, protoregistry.NotFound) {
+			return nil, query.ErrNotFound
+		}
+		return nil, err
+	}
+	xd := xt.TypeDescriptor()
+	if xd.ContainingMessage().FullName() != m.Descriptor().FullName() {
+		return nil, query.ErrNotFound
+	}
+	if xd.HasPresence() && !m.Has(xd) {
+		switch e.c.unset {
+		case unsetAsNotFound:
+			return nil, query.ErrNotFound
+		case unsetAsDefault:
+			// New returns an empty message for a message field instead of
+			// nil, like defaultValue does for a regular field.
+			return xt.InterfaceOf(xt.New()), nil
+		}
+		// Extract the nil pointer like an unset regular field, e.g.
+		// (*string)(nil) for an optional string extension.
+		typ := reflect.TypeOf(xt.InterfaceOf(xt.Zero()))
+		if typ.Kind() != reflect.Pointer {
+			typ = reflect.PointerTo(typ)
+		}
+		return reflect.Zero(typ).Interface(), nil
+	}
+	return proto.GetExtension(m.Interface(), xt), nil
+}
@@ -0,0 +1,175 @@
+package protobuf
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoregistry"
+
+	testpb "github.com/zoncoen/query-go/extractor/protobuf/testdata/gen/testpb"
+	"github.com/zoncoen/query-go/v2"
+)
+
+func TestExtractFunc_Extension(t *testing.T) {
+	msg := &testpb.LegacyMessage{
+		Name: proto.String("aaa"),
+	}
+	proto.SetExtension(msg, testpb.E_ExtName, "bbb")
+	proto.SetExtension(msg, testpb.E_ExtMessage, &testpb.LegacyMessage{Name: proto.String("ccc")})
+	proto.SetExtension(msg, testpb.E_ExtNumbers, []int32{1, 2})
+	proto.SetExtension(msg, testpb.E_ExtStatus, testpb.LegacyStatus_LEGACY_STATUS_OK)
+	const pkg = "com.github.zoncoen.querygo.extractor.protobuf."
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  string
+			v      any
+			opts   []Option
+			expect any
+		}{
+			"scalar": {
+				query:  "$['[" + pkg + "ext_name]']",
+				v:      msg,
+				expect: "bbb",
+			},
+			"message": {
+				query:  "$['[" + pkg + "ext_message]'].name",
+				v:      msg,
+				expect: proto.String("ccc"),
+			},
+			"repeated": {
+				query:  "$['[" + pkg + "ext_numbers]'][-1]",
+				v:      msg,
+				expect: int32(2),
+			},
+			"enum": {
+				query:  "$['[" + pkg + "ext_status]']",
+				v:      msg,
+				expect: testpb.LegacyStatus_LEGACY_STATUS_OK,
+			},
+			"enum as name": {
+				query:  "$['[" + pkg + "ext_status]']",
+				v:      msg,
+				opts:   []Option{EnumAsName()},
+				expect: "LEGACY_STATUS_OK",
+			},
+			"regular field": {
+				query:  "$.name",
+				v:      msg,
+				expect: proto.String("aaa"),
+			},
+			"unset repeated": {
+				query:  "$['[" + pkg + "ext_numbers]']",
+				v:      &testpb.LegacyMessage{},
+				expect: []int32(nil),
+			},
+			"unset": {
+				query:  "$['[" + pkg + "ext_name]']",
+				v:      &testpb.LegacyMessage{},
+				expect: (*string)(nil),
+			},
+			"unset message": {
+				query:  "$['[" + pkg + "ext_message]']",
+				v:      &testpb.LegacyMessage{},
+				expect: (*testpb.LegacyMessage)(nil),
+			},
+			"unset regular field": {
+				query:  "$.name",
+				v:      &testpb.LegacyMessage{},
+				expect: (*string)(nil),
+			},
+			"unset scalar with UnsetAsDefault": {
+				query:  "$['[" + pkg + "ext_name]']",
+				v:      &testpb.LegacyMessage{},
+				opts:   []Option{UnsetAsDefault()},
+				expect: "",
+			},
+			"unset regular field with UnsetAsDefault": {
+				query:  "$.name",
+				v:      &testpb.LegacyMessage{},
+				opts:   []Option{UnsetAsDefault()},
+				expect: "",
+			},
+			"unset with UnsetAsDefault": {
+				query:  "$['[" + pkg + "ext_message]'].count",
+				v:      &testpb.LegacyMessage{},
+				opts:   []Option{UnsetAsDefault()},
+				expect: int32(10),
+			},
+			"resolver": {
+				query: "$['[" + pkg + "ext_name]']",
+				v:     msg,
+				opts: []Option{ExtensionTypes(func() *protoregistry.Types {
+					r := &protoregistry.Types{}
+					if err := r.RegisterExtension(testpb.E_ExtName); err != nil {
+						t.Fatal(err)
+					}
+					return r
+				}())},
+				expect: "bbb",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				q, err := query.ParseString(test.query, query.CustomExtractFunc(ExtractFunc(test.opts...)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := q.Extract(context.Background(), test.v)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if !reflect.DeepEqual(got, test.expect) {
+					t.Errorf("expect %#v but got %#v", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query string
+			v     any
+			opts  []Option
+		}{
+			"unset with UnsetAsNotFound": {
+				query: "$['[" + pkg + "ext_name]']",
+				v:     &testpb.LegacyMessage{},
+				opts:  []Option{UnsetAsNotFound()},
+			},
+			"unset regular field with UnsetAsNotFound": {
+				query: "$.name",
+				v:     &testpb.LegacyMessage{},
+				opts:  []Option{UnsetAsNotFound()},
+			},
+			"unknown extension": {
+				query: "$['[" + pkg + "unknown]']",
+				v:     msg,
+			},
+			"extension of another message": {
+				query: "$['[" + pkg + "ext_name]']",
+				v:     &testpb.Message{},
+			},
+			"not registered": {
+				query: "$['[" + pkg + "ext_name]']",
+				v:     msg,
+				opts:  []Option{ExtensionTypes(&protoregistry.Types{})},
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				q, err := query.ParseString(test.query, query.CustomExtractFunc(ExtractFunc(test.opts...)))
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, err = q.Extract(context.Background(), test.v)
+				if !errors.Is(err, query.ErrNotFound) {
+					t.Fatalf("expect not found error but got %v", err)
+				}
+			})
+		}
+	})
+}
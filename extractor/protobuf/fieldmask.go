@@ -0,0 +1,160 @@
+package protobuf
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+// FieldMaskPathToQuery converts a field mask path like "a.b_c.d" to the
+// query which extracts the field. The path segments are proto field names,
+// so ExtractFunc and OneofIsInlineStructFieldFunc are added to opts to
+// resolve them by the protobuf struct tag, including the oneof members.
+func FieldMaskPathToQuery(path string, opts ...query.Option) (*query.Query, error) {
+	q := query.New(append([]query.Option{
+		query.CustomExtractFunc(ExtractFunc()),
+		query.CustomIsInlineStructFieldFunc(OneofIsInlineStructFieldFunc()),
+	}, opts...)...).Root()
+	for _, name := range strings.Split(path, ".") {
+		if name == "" {
+			return nil, fmt.Errorf("invalid field mask path %q: empty field name", path)
+		}
+		q = q.Key(name)
+	}
+	return q, nil
+}
+
+// FieldMaskToQueries converts each path of fm to a query by FieldMaskPathToQuery.
+func FieldMaskToQueries(fm *fieldmaskpb.FieldMask, opts ...query.Option) ([]*query.Query, error) {
+	qs := make([]*query.Query, 0, len(fm.GetPaths()))
+	for _, path := range fm.GetPaths() {
+		q, err := FieldMaskPathToQuery(path, opts...)
+		if err != nil {
+			return nil, err
+		}
+		qs = append(qs, q)
+	}
+	return qs, nil
+}
+
+// QueryToFieldMaskPath converts q to the field mask path of the message m.
+// Each key of q is resolved to a field of m by the proto field name or the
+// JSON name, like ExtractFunc does, and rendered as the proto field name.
+// It returns an error if q contains an index, which has no field mask
+// equivalent, or traverses into a repeated, map or scalar field.
+func QueryToFieldMaskPath(m proto.Message, q *query.Query) (string, error) {
+	fds, err := resolveFieldMaskPath(m.ProtoReflect().Descriptor(), q)
+	if err != nil {
+		return "", err
+	}
+	names := make([]string, len(fds))
+	for i, fd := range fds {
+		names[i] = string(fd.Name())
+	}
+	return strings.Join(names, "."), nil
+}
+
+// QueriesToFieldMask converts qs to the field mask of the message m by
+// QueryToFieldMaskPath.
+func QueriesToFieldMask(m proto.Message, qs ...*query.Query) (*fieldmaskpb.FieldMask, error) {
+	fm := &fieldmaskpb.FieldMask{}
+	for _, q := range qs {
+		path, err := QueryToFieldMaskPath(m, q)
+		if err != nil {
+			return nil, err
+		}
+		fm.Paths = append(fm.Paths, path)
+	}
+	return fm, nil
+}
+
+// Prune clears all fields of m except the ones selected by qs, as an update
+// with the field mask converted by QueriesToFieldMask would keep them.
+func Prune(m proto.Message, qs ...*query.Query) error {
+	root := fieldMaskTree{}
+	md := m.ProtoReflect().Descriptor()
+	for _, q := range qs {
+		fds, err := resolveFieldMaskPath(md, q)
+		if err != nil {
+			return err
+		}
+		root.add(fds)
+	}
+	root.prune(m.ProtoReflect())
+	return nil
+}
+
+func resolveFieldMaskPath(md protoreflect.MessageDescriptor, q *query.Query) ([]protoreflect.FieldDescriptor, error) {
+	es := q.Extractors()
+	if len(es) == 0 {
+		return nil, errors.New("empty query has no field mask equivalent")
+	}
+	fds := make([]protoreflect.FieldDescriptor, 0, len(es))
+	for i, e := range es {
+		k, ok := e.(*query.Key)
+		if !ok {
+			return nil, fmt.Errorf("%s: %s has no field mask equivalent", q, e)
+		}
+		if md == nil {
+			return nil, fmt.Errorf("%s: can not select %s in %s field %s", q, e, fds[i-1].Kind(), fds[i-1].FullName())
+		}
+		fd := md.Fields().ByName(protoreflect.Name(k.Key()))
+		if fd == nil {
+			fd = md.Fields().ByJSONName(k.Key())
+		}
+		if fd == nil {
+			return nil, fmt.Errorf("%s: field %s not found in %s", q, e, md.FullName())
+		}
+		fds = append(fds, fd)
+		md = nil
+		if fd.Message() != nil && fd.Cardinality() != protoreflect.Repeated {
+			md = fd.Message()
+		}
+	}
+	return fds, nil
+}
+
+// fieldMaskTree represents the fields selected by field mask paths. A nil
+// subtree means the whole field is selected.
+type fieldMaskTree map[protoreflect.Name]fieldMaskTree
+
+func (t fieldMaskTree) add(fds []protoreflect.FieldDescriptor) {
+	name := fds[0].Name()
+	sub, ok := t[name]
+	if ok && sub == nil {
+		// the whole field is already selected
+		return
+	}
+	if len(fds) == 1 {
+		t[name] = nil
+		return
+	}
+	if sub == nil {
+		sub = fieldMaskTree{}
+		t[name] = sub
+	}
+	sub.add(fds[1:])
+}
+
+func (t fieldMaskTree) prune(m protoreflect.Message) {
+	var cleared []protoreflect.FieldDescriptor
+	m.Range(func(fd protoreflect.FieldDescriptor, v protoreflect.Value) bool {
+		sub, ok := t[fd.Name()]
+		switch {
+		case !ok:
+			cleared = append(cleared, fd)
+		case sub != nil:
+			sub.prune(v.Message())
+		}
+		return true
+	})
+	for _, fd := range cleared {
+		m.Clear(fd)
+	}
+}
@@ -0,0 +1,305 @@
+package protobuf
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/fieldmaskpb"
+
+	testpb "github.com/zoncoen/query-go/extractor/protobuf/testdata/gen/testpb"
+	"github.com/zoncoen/query-go/v2"
+)
+
+func TestFieldMaskPathToQuery(t *testing.T) {
+	msg := &testpb.Message{
+		Nested: &testpb.Message_Nested{
+			Child: &testpb.Message_Nested{
+				Value: "aaa",
+			},
+		},
+	}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			path         string
+			msg          *testpb.Message
+			expectString string
+			expect       any
+		}{
+			"nested field": {
+				path:         "nested.child.value",
+				msg:          msg,
+				expectString: "$.nested.child.value",
+				expect:       "aaa",
+			},
+			"oneof member": {
+				path:         "text",
+				msg:          &testpb.Message{Payload: &testpb.Message_Text{Text: "bbb"}},
+				expectString: "$.text",
+				expect:       "bbb",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				q, err := FieldMaskPathToQuery(test.path)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got := q.String(); got != test.expectString {
+					t.Errorf("expect %q but got %q", test.expectString, got)
+				}
+				got, err := q.Extract(context.Background(), test.msg)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expect {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+				path, err := QueryToFieldMaskPath(test.msg, q)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if path != test.path {
+					t.Errorf("expect %q but got %q", test.path, path)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			path   string
+			expect string
+		}{
+			"empty": {
+				path:   "",
+				expect: `invalid field mask path "": empty field name`,
+			},
+			"empty field name": {
+				path:   "nested..value",
+				expect: `invalid field mask path "nested..value": empty field name`,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				_, err := FieldMaskPathToQuery(test.path)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); got != test.expect {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+}
+
+func TestFieldMaskToQueries(t *testing.T) {
+	qs, err := FieldMaskToQueries(&fieldmaskpb.FieldMask{
+		Paths: []string{"name", "nested.value"},
+	}, query.CaseInsensitive())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	strs := make([]string, len(qs))
+	for i, q := range qs {
+		strs[i] = q.String()
+	}
+	if got, expect := strings.Join(strs, ","), "$.name,$.nested.value"; got != expect {
+		t.Errorf("expect %q but got %q", expect, got)
+	}
+}
+
+func TestQueryToFieldMaskPath(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  string
+			expect string
+		}{
+			"field": {
+				query:  "$.name",
+				expect: "name",
+			},
+			"nested field": {
+				query:  "nested.child.value",
+				expect: "nested.child.value",
+			},
+			"json name": {
+				query:  "nestedList",
+				expect: "nested_list",
+			},
+			"map field": {
+				query:  "nested_map",
+				expect: "nested_map",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				q, err := query.ParseString(test.query)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := QueryToFieldMaskPath(&testpb.Message{}, q)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expect {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query  string
+			expect string
+		}{
+			"empty": {
+				query:  "",
+				expect: "empty query has no field mask equivalent",
+			},
+			"index": {
+				query:  "nested_list[0]",
+				expect: ".nested_list[0]: [0] has no field mask equivalent",
+			},
+			"unknown field": {
+				query:  "nested.foo",
+				expect: ".nested.foo: field .foo not found in com.github.zoncoen.querygo.extractor.protobuf.Message.Nested",
+			},
+			"into repeated field": {
+				query:  "nested_list.value",
+				expect: ".nested_list.value: can not select .value in message field com.github.zoncoen.querygo.extractor.protobuf.Message.nested_list",
+			},
+			"into map field": {
+				query:  "nested_map.key",
+				expect: ".nested_map.key: can not select .key in message field com.github.zoncoen.querygo.extractor.protobuf.Message.nested_map",
+			},
+			"into scalar field": {
+				query:  "name.foo",
+				expect: ".name.foo: can not select .foo in string field com.github.zoncoen.querygo.extractor.protobuf.Message.name",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				q, err := query.ParseString(test.query)
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, err = QueryToFieldMaskPath(&testpb.Message{}, q)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); got != test.expect {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+}
+
+func TestQueriesToFieldMask(t *testing.T) {
+	fm, err := QueriesToFieldMask(&testpb.Message{}, query.New().Key("name"), query.New().Key("nested").Key("value"))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if got, expect := strings.Join(fm.GetPaths(), ","), "name,nested.value"; got != expect {
+		t.Errorf("expect %q but got %q", expect, got)
+	}
+	if !fm.IsValid(&testpb.Message{}) {
+		t.Error("invalid field mask")
+	}
+	if _, err := QueriesToFieldMask(&testpb.Message{}, query.New().Index(0)); err == nil {
+		t.Error("no error")
+	}
+}
+
+func TestPrune(t *testing.T) {
+	newMessage := func() *testpb.Message {
+		return &testpb.Message{
+			Name:  "name",
+			Count: 1,
+			Nested: &testpb.Message_Nested{
+				Value: "value",
+				Child: &testpb.Message_Nested{
+					Value: "child",
+				},
+			},
+			NestedList: []*testpb.Message_Nested{{Value: "a"}},
+			NestedMap: map[string]*testpb.Message_Nested{
+				"key": {Value: "b"},
+			},
+		}
+	}
+	tests := map[string]struct {
+		queries []*query.Query
+		expect  *testpb.Message
+	}{
+		"no queries": {
+			expect: &testpb.Message{},
+		},
+		"fields": {
+			queries: []*query.Query{
+				query.New().Key("name"),
+				query.New().Key("nested_map"),
+			},
+			expect: &testpb.Message{
+				Name: "name",
+				NestedMap: map[string]*testpb.Message_Nested{
+					"key": {Value: "b"},
+				},
+			},
+		},
+		"nested field": {
+			queries: []*query.Query{
+				query.New().Key("nested").Key("child"),
+			},
+			expect: &testpb.Message{
+				Nested: &testpb.Message_Nested{
+					Child: &testpb.Message_Nested{
+						Value: "child",
+					},
+				},
+			},
+		},
+		"whole field wins": {
+			queries: []*query.Query{
+				query.New().Key("nested").Key("child"),
+				query.New().Key("nested"),
+				query.New().Key("nested").Key("value"),
+			},
+			expect: &testpb.Message{
+				Nested: &testpb.Message_Nested{
+					Value: "value",
+					Child: &testpb.Message_Nested{
+						Value: "child",
+					},
+				},
+			},
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			msg := newMessage()
+			if err := Prune(msg, test.queries...); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !proto.Equal(msg, test.expect) {
+				t.Errorf("expect %v but got %v", test.expect, msg)
+			}
+		})
+	}
+	t.Run("failure", func(t *testing.T) {
+		msg := newMessage()
+		if err := Prune(msg, query.New().Key("nested_list").Index(0)); err == nil {
+			t.Fatal("no error")
+		}
+		if !proto.Equal(msg, newMessage()) {
+			t.Errorf("message is modified: %v", msg)
+		}
+	})
+}
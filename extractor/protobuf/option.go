@@ -0,0 +1,113 @@
+package protobuf
+
+import (
+	"reflect"
+
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+// Option represents an option for ExtractFunc.
+type Option func(*config)
+
+type config struct {
+	unwrapWellKnownTypes bool
+	enumAsName           bool
+	unset                unsetMode
+	extensionTypes       protoregistry.ExtensionTypeResolver
+}
+
+type unsetMode int
+
+const (
+	unsetAsIs unsetMode = iota
+	unsetAsNotFound
+	unsetAsDefault
+)
+
+func newConfig(opts []Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// UnwrapWellKnownTypes returns the Option to extract the well-known types as
+// their natural Go values, so that the same query works against a protobuf
+// message and its JSON representation:
+//
+//   - wrappers (e.g. *wrapperspb.StringValue) are unwrapped to their scalar
+//   - *structpb.Struct is traversed as map[string]any
+//   - *structpb.ListValue is traversed as []any
+//   - *structpb.Value is unwrapped to the value of its kind
+//   - *timestamppb.Timestamp is extracted as time.Time
+//   - *durationpb.Duration is extracted as time.Duration
+//
+// A nil message of these types is extracted as nil. Repeated fields and map
+// values of these types are converted element-wise.
+func UnwrapWellKnownTypes() Option {
+	return func(c *config) {
+		c.unwrapWellKnownTypes = true
+	}
+}
+
+// EnumAsName returns the Option to extract enum values as the names of the
+// values (e.g. "STATUS_ACTIVE") instead of the generated Go enum type. A
+// number without a name is extracted as the decimal string of the number.
+// Repeated enum fields are extracted as []string, and map fields with enum
+// values as maps with string values. Use ParseEnum to convert a name back to
+// the enum value.
+func EnumAsName() Option {
+	return func(c *config) {
+		c.enumAsName = true
+	}
+}
+
+// UnsetAsNotFound returns the Option to report an unset field with presence
+// (a message field, or a scalar field with the optional label) as not found
+// instead of extracting the nil pointer, so that it is distinguishable from
+// a field set to the zero value. Fields without presence, such as proto3
+// scalar fields without the optional label, are extracted as they are.
+//
+// It overrides UnsetAsDefault.
+func UnsetAsNotFound() Option {
+	return func(c *config) {
+		c.unset = unsetAsNotFound
+	}
+}
+
+// UnsetAsDefault returns the Option to extract an unset field with presence
+// as its default value like the generated getter does. A set scalar field
+// with the optional label is also dereferenced like the getter, so that the
+// type of the value does not depend on the presence, except that an unset
+// message field is extracted as an empty message instead of nil, so that a
+// query like "$.a.b.c" does not fail on an unset intermediate message. The
+// fields of an unset oneof are still not found.
+//
+// It overrides UnsetAsNotFound.
+func UnsetAsDefault() Option {
+	return func(c *config) {
+		c.unset = unsetAsDefault
+	}
+}
+
+// ExtensionTypes returns the Option to resolve extension fields by r instead
+// of protoregistry.GlobalTypes. An unset extension field with presence
+// follows UnsetAsNotFound and UnsetAsDefault like a regular field, and it is
+// extracted as the nil pointer of its type by default.
+func ExtensionTypes(r protoregistry.ExtensionTypeResolver) Option {
+	return func(c *config) {
+		c.extensionTypes = r
+	}
+}
+
+// convert converts v according to the options.
+func (c *config) convert(v reflect.Value) reflect.Value {
+	if c.unwrapWellKnownTypes {
+		v = unwrapWellKnownType(v)
+	}
+	if c.enumAsName {
+		v = enumToName(v)
+	}
+	return v
+}
@@ -4,62 +4,111 @@ import (
 	"context"
 	"errors"
 	"reflect"
+	"strconv"
 	"strings"
 
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
 	"github.com/zoncoen/query-go/v2"
 )
 
 // ExtractFunc is a function for query.CustomExtractFunc option to extract values by protobuf struct tag.
-func ExtractFunc() func(query.ExtractFunc) query.ExtractFunc {
+// A field can be selected by the proto field name, the JSON name, or the field number prefixed with "#" (e.g. "$['#3']").
+// An extension field can be selected by the full name enclosed in brackets like the text format (e.g. "$['[pkg.ext_name]']").
+// The behavior can be customized by opts.
+func ExtractFunc(opts ...Option) func(query.ExtractFunc) query.ExtractFunc {
+	c := newConfig(opts)
 	return func(f query.ExtractFunc) query.ExtractFunc {
 		return func(ctx context.Context, in reflect.Value) (reflect.Value, error) {
-			v := in
-			for {
-				if v.IsValid() {
-					if k := v.Kind(); k == reflect.Interface || k == reflect.Pointer {
-						v = v.Elem()
-						continue
-					}
-				}
-				break
+			v, err := c.extract(ctx, f, c.convert(in))
+			if err != nil {
+				return reflect.Value{}, err
 			}
-			switch v.Kind() {
-			case reflect.Struct:
-				for i := 0; i < v.Type().NumField(); i++ {
-					field := v.Type().FieldByIndex([]int{i})
-					if s := field.Tag.Get("protobuf"); s != "" {
-						v, err := f(ctx, reflect.ValueOf(&keyExtractor{v}))
-						if err == nil {
-							return v, nil
-						}
-						if !errors.Is(err, query.ErrNotFound) {
-							// A failure is not an absence: do not fall back to
-							// the next field or the plain struct lookup, which
-							// would mask e.g. a canceled blocking extractor as
-							// "not found".
-							return reflect.Value{}, err
-						}
-					}
+			return c.convert(v), nil
+		}
+	}
+}
+
+func (c *config) extract(ctx context.Context, f query.ExtractFunc, in reflect.Value) (reflect.Value, error) {
+	v := in
+	for {
+		if v.IsValid() {
+			if k := v.Kind(); k == reflect.Interface || k == reflect.Pointer {
+				v = v.Elem()
+				continue
+			}
+		}
+		break
+	}
+	switch v.Kind() {
+	case reflect.Struct:
+		for i := 0; i < v.Type().NumField(); i++ {
+			field := v.Type().FieldByIndex([]int{i})
+			if field.Tag.Get("protobuf") != "" || field.Tag.Get("protobuf_oneof") != "" {
+				v, err := f(ctx, reflect.ValueOf(&keyExtractor{v: v, c: c}))
+				if err == nil {
+					return v, nil
+				}
+				if !errors.Is(err, query.ErrNotFound) {
+					// A failure is not an absence: do not fall back to
+					// the next field or the plain struct lookup, which
+					// would mask e.g. a canceled blocking extractor as
+					// "not found".
+					return reflect.Value{}, err
 				}
 			}
-			return f(ctx, in)
 		}
 	}
+	return f(ctx, in)
 }
 
+// OneofCaseKey is the virtual key to extract the proto field name of the
+// populated field of a oneof, e.g. "$.payload['@case']". The oneof itself is
+// extracted by its proto name ("$.payload"), which is not found if no field
+// of the oneof is populated.
+const OneofCaseKey = "@case"
+
 type keyExtractor struct {
 	v reflect.Value
+	c *config
 }
 
 // ExtractByKey implements the query.KeyExtractor interface.
 func (e *keyExtractor) ExtractByKey(ctx context.Context, key string) (any, error) {
+	if name, ok := extensionName(key); ok && e.v.Kind() == reflect.Struct {
+		return e.extractExtension(name)
+	}
 	ci := query.IsCaseInsensitive(ctx)
 	if ci {
 		key = strings.ToLower(key)
 	}
 	switch e.v.Kind() {
 	case reflect.Struct:
+		if key == OneofCaseKey {
+			if name, ok := oneofCase(e.v.Type()); ok {
+				return name, nil
+			}
+		}
+		if s, ok := strings.CutPrefix(key, "#"); ok {
+			if num, err := strconv.Atoi(s); err == nil {
+				return e.extractByFieldNumber(num)
+			}
+		}
 		for i := 0; i < e.v.Type().NumField(); i++ {
+			if s := e.v.Type().FieldByIndex([]int{i}).Tag.Get("protobuf_oneof"); s != "" {
+				if ci {
+					s = strings.ToLower(s)
+				}
+				if s == key {
+					// The oneof field holds a wrapper of the populated field,
+					// or nil if no field is populated.
+					if field := e.v.Field(i); field.CanInterface() && !field.IsNil() {
+						return field.Interface(), nil
+					}
+					return nil, query.ErrNotFound
+				}
+			}
 			if s := e.v.Type().FieldByIndex([]int{i}).Tag.Get("protobuf"); s != "" {
 				for _, opt := range strings.Split(s, ",") {
 					kv := strings.Split(opt, "=")
@@ -70,11 +119,7 @@ func (e *keyExtractor) ExtractByKey(ctx context.Context, key string) (any, error
 								v = strings.ToLower(v)
 							}
 							if v == key {
-								var resp any
-								if field := e.v.Field(i); field.CanInterface() {
-									resp = field.Interface()
-								}
-								return resp, nil
+								return e.field(i)
 							}
 						}
 					}
@@ -85,6 +130,157 @@ func (e *keyExtractor) ExtractByKey(ctx context.Context, key string) (any, error
 	return nil, query.ErrNotFound
 }
 
+// Keys implements the query.KeyLister interface. It returns the proto field
+// names, the JSON names and the oneof names of the message.
+func (e *keyExtractor) Keys(_ context.Context) []string {
+	if e.v.Kind() != reflect.Struct {
+		return nil
+	}
+	var keys []string
+	for i := 0; i < e.v.Type().NumField(); i++ {
+		tag := e.v.Type().Field(i).Tag
+		if s := tag.Get("protobuf_oneof"); s != "" {
+			keys = append(keys, s)
+		}
+		for _, opt := range strings.Split(tag.Get("protobuf"), ",") {
+			if k, v, ok := strings.Cut(opt, "="); ok && (k == "name" || k == "json") {
+				keys = append(keys, v)
+			}
+		}
+	}
+	return keys
+}
+
+// extractByFieldNumber extracts the field whose field number is num. The
+// fields of a oneof are looked up in the populated field only.
+func (e *keyExtractor) extractByFieldNumber(num int) (any, error) {
+	for i := 0; i < e.v.NumField(); i++ {
+		field, tag := e.v.Field(i), e.v.Type().Field(i).Tag
+		oneof := tag.Get("protobuf_oneof") != ""
+		if oneof {
+			if field.IsNil() {
+				continue
+			}
+			field = field.Elem()
+			if field.Kind() == reflect.Pointer {
+				field = field.Elem()
+			}
+			if field.Kind() != reflect.Struct || field.NumField() != 1 {
+				continue
+			}
+			field, tag = field.Field(0), field.Type().Field(0).Tag
+		}
+		if n, ok := fieldNumber(tag); ok && n == num {
+			if oneof {
+				var resp any
+				if field.CanInterface() {
+					resp = field.Interface()
+				}
+				return resp, nil
+			}
+			return e.field(i)
+		}
+	}
+	return nil, query.ErrNotFound
+}
+
+// field returns the i-th field of the message. An unset field with presence
+// is extracted according to the options.
+func (e *keyExtractor) field(i int) (any, error) {
+	field := e.v.Field(i)
+	if e.c.unset != unsetAsIs {
+		if fd, m, ok := e.fieldDescriptor(i); ok && fd.HasPresence() {
+			switch {
+			case !m.Has(fd) && e.c.unset == unsetAsNotFound:
+				return nil, query.ErrNotFound
+			case !m.Has(fd):
+				return defaultValue(field.Type(), fd), nil
+			case e.c.unset == unsetAsDefault && fd.Message() == nil && field.Kind() == reflect.Pointer && field.CanInterface():
+				// Dereference a set optional scalar like the generated
+				// getter, so that the type does not depend on presence.
+				return field.Elem().Interface(), nil
+			}
+		}
+	}
+	if !field.CanInterface() {
+		return nil, nil
+	}
+	return field.Interface(), nil
+}
+
+// fieldDescriptor returns the descriptor of the i-th field of the message.
+func (e *keyExtractor) fieldDescriptor(i int) (protoreflect.FieldDescriptor, protoreflect.Message, bool) {
+	m, ok := e.message()
+	if !ok {
+		return nil, nil, false
+	}
+	n, ok := fieldNumber(e.v.Type().Field(i).Tag)
+	if !ok {
+		return nil, nil, false
+	}
+	fd := m.Descriptor().Fields().ByNumber(protoreflect.FieldNumber(n))
+	return fd, m, fd != nil
+}
+
+// message returns the message of e.v, or false if e.v is not a message (e.g.
+// a wrapper of a oneof field).
+func (e *keyExtractor) message() (protoreflect.Message, bool) {
+	v := e.v
+	if !v.CanAddr() {
+		p := reflect.New(v.Type())
+		p.Elem().Set(v)
+		v = p.Elem()
+	}
+	m, ok := v.Addr().Interface().(proto.Message)
+	if !ok {
+		return nil, false
+	}
+	return m.ProtoReflect(), true
+}
+
+// defaultValue returns the value of an unset field of the Go type typ like
+// the generated getter does, except that an unset message field is an empty
+// message instead of nil so that the subsequent fields can be extracted.
+func defaultValue(typ reflect.Type, fd protoreflect.FieldDescriptor) any {
+	if typ.Kind() == reflect.Pointer {
+		typ = typ.Elem()
+	}
+	if fd.Message() != nil {
+		return reflect.New(typ).Interface()
+	}
+	return reflect.ValueOf(fd.Default().Interface()).Convert(typ).Interface()
+}
+
+// fieldNumber returns the field number in the protobuf struct tag, e.g. 3 of
+// `protobuf:"bytes,3,opt,name=foo"`.
+func fieldNumber(tag reflect.StructTag) (int, bool) {
+	opts := strings.Split(tag.Get("protobuf"), ",")
+	if len(opts) < 2 {
+		return 0, false
+	}
+	n, err := strconv.Atoi(opts[1])
+	return n, err == nil
+}
+
+// oneofCase returns the proto field name of the populated field if typ is a
+// generated wrapper type of a oneof field.
+func oneofCase(typ reflect.Type) (string, bool) {
+	if typ.NumField() != 1 {
+		return "", false
+	}
+	var name string
+	var oneof bool
+	for _, opt := range strings.Split(typ.Field(0).Tag.Get("protobuf"), ",") {
+		switch k, v, _ := strings.Cut(opt, "="); k {
+		case "name":
+			name = v
+		case "oneof":
+			oneof = true
+		}
+	}
+	return name, oneof && name != ""
+}
+
 // OneofIsInlineStructFieldFunc is a function for query.CustomIsInlineStructFieldFunc option to enable extracting values even if the oneof field name is omitted.
 func OneofIsInlineStructFieldFunc() func(reflect.StructField) bool {
 	return func(f reflect.StructField) bool {
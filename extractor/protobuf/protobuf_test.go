@@ -198,3 +198,395 @@ func TestExtractFunc_PropagatesFailures(t *testing.T) {
 		t.Fatalf("expected context.Canceled to propagate but got: %s", err)
 	}
 }
+
+func TestOneofCaseKey(t *testing.T) {
+	msg := &testpb.Message{
+		Payload: &testpb.Message_Text{
+			Text: "aaa",
+		},
+	}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			v      any
+			expect any
+		}{
+			"case": {
+				query: query.New(
+					query.CustomExtractFunc(ExtractFunc()),
+				).Key("payload").Key(OneofCaseKey),
+				v:      msg,
+				expect: "text",
+			},
+			"case of message": {
+				query: query.New(
+					query.CustomExtractFunc(ExtractFunc()),
+				).Key("value").Key(OneofCaseKey),
+				v: &testpb.OneofMessage{
+					Value: &testpb.OneofMessage_B_{
+						B: &testpb.OneofMessage_B{},
+					},
+				},
+				expect: "b",
+			},
+			"case (case insensitive)": {
+				query: query.New(
+					query.CaseInsensitive(),
+					query.CustomExtractFunc(ExtractFunc()),
+				).Key("PAYLOAD").Key("@CASE"),
+				v:      msg,
+				expect: "text",
+			},
+			"populated field": {
+				query: query.New(
+					query.CustomExtractFunc(ExtractFunc()),
+				).Key("payload").Key("text"),
+				v:      msg,
+				expect: "aaa",
+			},
+			"populated field (inline)": {
+				query: query.New(
+					query.CustomExtractFunc(ExtractFunc()),
+					query.CustomIsInlineStructFieldFunc(OneofIsInlineStructFieldFunc()),
+				).Key("text"),
+				v:      msg,
+				expect: "aaa",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				got, err := test.query.Extract(context.Background(), test.v)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expect {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query    *query.Query
+			v        any
+			failedAt string
+		}{
+			"unset oneof": {
+				query: query.New(
+					query.CustomExtractFunc(ExtractFunc()),
+				).Key("payload"),
+				v:        &testpb.Message{},
+				failedAt: ".payload",
+			},
+			"case of unset oneof": {
+				query: query.New(
+					query.CustomExtractFunc(ExtractFunc()),
+				).Key("payload").Key(OneofCaseKey),
+				v:        &testpb.Message{},
+				failedAt: ".payload",
+			},
+			"unset field": {
+				query: query.New(
+					query.CustomExtractFunc(ExtractFunc()),
+				).Key("payload").Key("nested_payload"),
+				v:        msg,
+				failedAt: ".payload.nested_payload",
+			},
+			"unset field (inline)": {
+				query: query.New(
+					query.CustomExtractFunc(ExtractFunc()),
+					query.CustomIsInlineStructFieldFunc(OneofIsInlineStructFieldFunc()),
+				).Key("nested_payload"),
+				v:        msg,
+				failedAt: ".nested_payload",
+			},
+			"case of message": {
+				query: query.New(
+					query.CustomExtractFunc(ExtractFunc()),
+				).Key(OneofCaseKey),
+				v:        msg,
+				failedAt: ".@case",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				_, err := test.query.Extract(context.Background(), test.v)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				var nfe *query.NotFoundError
+				if !errors.As(err, &nfe) {
+					t.Fatalf("expect not found error but got %s", err)
+				}
+				if nfe.FailedAt != test.failedAt {
+					t.Errorf("expect failed at %q but got %q", test.failedAt, nfe.FailedAt)
+				}
+			})
+		}
+	})
+}
+
+func TestExtractFunc_FieldNumber(t *testing.T) {
+	msg := &testpb.Message{
+		Name: "aaa",
+		NestedList: []*testpb.Message_Nested{
+			{Value: "bbb"},
+		},
+		Payload: &testpb.Message_NestedPayload{
+			NestedPayload: &testpb.Message_Nested{
+				Value: "ccc",
+			},
+		},
+	}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  string
+			v      any
+			expect any
+		}{
+			"field": {
+				query:  "$['#1']",
+				v:      msg,
+				expect: "aaa",
+			},
+			"repeated field": {
+				query:  "$['#4'][0]['#1']",
+				v:      msg,
+				expect: "bbb",
+			},
+			"oneof field": {
+				query:  "$['#10']['#1']",
+				v:      msg,
+				expect: "ccc",
+			},
+			"oneof field of message": {
+				query: "$['#2'].bar_value",
+				v: &testpb.OneofMessage{
+					Value: &testpb.OneofMessage_B_{
+						B: &testpb.OneofMessage_B{
+							BarValue: "yyy",
+						},
+					},
+				},
+				expect: "yyy",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				q, err := query.ParseString(test.query, query.CustomExtractFunc(ExtractFunc()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				got, err := q.Extract(context.Background(), test.v)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expect {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query  string
+			expect string
+		}{
+			"unknown field number": {
+				query:  "$['#100']",
+				expect: `"$.#100" not found`,
+			},
+			"unset oneof field": {
+				query:  "$['#9']",
+				expect: `"$.#9" not found`,
+			},
+			"not a number": {
+				query:  "$['#a']",
+				expect: `"$.#a" not found`,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				q, err := query.ParseString(test.query, query.CustomExtractFunc(ExtractFunc()))
+				if err != nil {
+					t.Fatal(err)
+				}
+				_, err = q.Extract(context.Background(), msg)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); got != test.expect {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("string", func(t *testing.T) {
+		q := query.New().Root().Key("#4").Index(0).Key("#1")
+		if got, expect := q.String(), "$.#4[0].#1"; got != expect {
+			t.Fatalf("expect %q but got %q", expect, got)
+		}
+		reparsed, err := query.ParseString(q.String(), query.CustomExtractFunc(ExtractFunc()))
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := reparsed.Extract(context.Background(), msg)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if expect := "bbb"; got != expect {
+			t.Errorf("expect %v but got %v", expect, got)
+		}
+	})
+}
+
+func TestExtractFunc_Unset(t *testing.T) {
+	zero := int32(0)
+	tests := map[string]struct {
+		query     *query.Query
+		v         any
+		asIs      any
+		asDefault any
+		found     bool
+	}{
+		"unset optional scalar": {
+			query:     query.New().Key("optional_count"),
+			v:         &testpb.Message{},
+			asIs:      (*int32)(nil),
+			asDefault: int32(0),
+		},
+		"optional scalar set to zero": {
+			query:     query.New().Key("optional_count"),
+			v:         &testpb.Message{OptionalCount: &zero},
+			asIs:      &zero,
+			asDefault: int32(0),
+			found:     true,
+		},
+		"optional enum set": {
+			query:     query.New().Key("optional_status"),
+			v:         &testpb.Message{OptionalStatus: testpb.Status_STATUS_ACTIVE.Enum()},
+			asIs:      testpb.Status_STATUS_ACTIVE.Enum(),
+			asDefault: testpb.Status_STATUS_ACTIVE,
+			found:     true,
+		},
+		"unset optional enum": {
+			query:     query.New().Key("optional_status"),
+			v:         &testpb.Message{},
+			asIs:      (*testpb.Status)(nil),
+			asDefault: testpb.Status_STATUS_UNSPECIFIED,
+		},
+		"unset optional scalar by field number": {
+			query:     query.New().Key("#11"),
+			v:         &testpb.Message{},
+			asIs:      (*int32)(nil),
+			asDefault: int32(0),
+		},
+		"unset message": {
+			query:     query.New().Key("nested").Key("child").Key("value"),
+			v:         &testpb.Message{},
+			asDefault: "",
+		},
+		"scalar without presence": {
+			query:     query.New().Key("count"),
+			v:         testpb.Message{},
+			asIs:      int32(0),
+			asDefault: int32(0),
+			found:     true,
+		},
+		"repeated field": {
+			query:     query.New().Key("statuses"),
+			v:         &testpb.Message{},
+			asIs:      []testpb.Status(nil),
+			asDefault: []testpb.Status(nil),
+			found:     true,
+		},
+	}
+	extract := func(t *testing.T, q *query.Query, v any, opts ...Option) (any, error) {
+		t.Helper()
+		return query.New(
+			query.CustomExtractFunc(ExtractFunc(opts...)),
+		).Append(q.Extractors()...).Extract(context.Background(), v)
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			t.Run("as is", func(t *testing.T) {
+				got, err := extract(t, test.query, test.v)
+				if test.asIs == nil {
+					if err == nil {
+						t.Fatal("no error")
+					}
+					return
+				}
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if !reflect.DeepEqual(got, test.asIs) {
+					t.Errorf("expect %#v but got %#v", test.asIs, got)
+				}
+			})
+			t.Run("UnsetAsNotFound", func(t *testing.T) {
+				_, err := extract(t, test.query, test.v, UnsetAsNotFound())
+				if test.found {
+					if err != nil {
+						t.Fatalf("unexpected error: %s", err)
+					}
+					return
+				}
+				if !errors.Is(err, query.ErrNotFound) {
+					t.Fatalf("expect not found error but got %v", err)
+				}
+			})
+			t.Run("UnsetAsDefault", func(t *testing.T) {
+				got, err := extract(t, test.query, test.v, UnsetAsNotFound(), UnsetAsDefault())
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if !reflect.DeepEqual(got, test.asDefault) {
+					t.Errorf("expect %#v but got %#v", test.asDefault, got)
+				}
+			})
+		})
+	}
+	t.Run("unset oneof with UnsetAsDefault", func(t *testing.T) {
+		_, err := extract(t, query.New().Key("payload").Key("text"), &testpb.Message{}, UnsetAsDefault())
+		if !errors.Is(err, query.ErrNotFound) {
+			t.Fatalf("expect not found error but got %v", err)
+		}
+	})
+	t.Run("unset well-known type with UnsetAsDefault", func(t *testing.T) {
+		got, err := extract(t, query.New().Key("string_value"), &testpb.WellKnownTypesMessage{}, UnwrapWellKnownTypes(), UnsetAsDefault())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != "" {
+			t.Errorf("expect empty string but got %#v", got)
+		}
+	})
+}
+
+func TestKeyExtractor_Keys(t *testing.T) {
+	_, err := query.New(query.Suggestions(), query.CustomExtractFunc(ExtractFunc())).Key("nested_lst").Extract(context.Background(), &testpb.Message{})
+	var nfe *query.NotFoundError
+	if !errors.As(err, &nfe) {
+		t.Fatalf("expected *query.NotFoundError but got %v", err)
+	}
+	for _, key := range []string{"nested_list", "nestedList", "payload"} {
+		found := false
+		for _, k := range nfe.Available {
+			if k == key {
+				found = true
+			}
+		}
+		if !found {
+			t.Errorf("%q is not available: %v", key, nfe.Available)
+		}
+	}
+	if len(nfe.Suggestions) == 0 || nfe.Suggestions[0] != "nested_list" {
+		t.Errorf("expect nested_list to be the closest but got %v", nfe.Suggestions)
+	}
+}
@@ -0,0 +1,314 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.32.0
+// 	protoc        v4.25.1
+// source: testpb/legacy.proto
+
+package testpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type LegacyStatus int32
+
+const (
+	LegacyStatus_LEGACY_STATUS_UNKNOWN LegacyStatus = 0
+	LegacyStatus_LEGACY_STATUS_OK      LegacyStatus = 1
+)
+
+// Enum value maps for LegacyStatus.
+var (
+	LegacyStatus_name = map[int32]string{
+		0: "LEGACY_STATUS_UNKNOWN",
+		1: "LEGACY_STATUS_OK",
+	}
+	LegacyStatus_value = map[string]int32{
+		"LEGACY_STATUS_UNKNOWN": 0,
+		"LEGACY_STATUS_OK":      1,
+	}
+)
+
+func (x LegacyStatus) Enum() *LegacyStatus {
+	p := new(LegacyStatus)
+	*p = x
+	return p
+}
+
+func (x LegacyStatus) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LegacyStatus) Descriptor() protoreflect.EnumDescriptor {
+	return file_testpb_legacy_proto_enumTypes[0].Descriptor()
+}
+
+func (LegacyStatus) Type() protoreflect.EnumType {
+	return &file_testpb_legacy_proto_enumTypes[0]
+}
+
+func (x LegacyStatus) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Do not use.
+func (x *LegacyStatus) UnmarshalJSON(b []byte) error {
+	num, err := protoimpl.X.UnmarshalJSONEnum(x.Descriptor(), b)
+	if err != nil {
+		return err
+	}
+	*x = LegacyStatus(num)
+	return nil
+}
+
+// Deprecated: Use LegacyStatus.Descriptor instead.
+func (LegacyStatus) EnumDescriptor() ([]byte, []int) {
+	return file_testpb_legacy_proto_rawDescGZIP(), []int{0}
+}
+
+type LegacyMessage struct {
+	state           protoimpl.MessageState
+	sizeCache       protoimpl.SizeCache
+	unknownFields   protoimpl.UnknownFields
+	extensionFields protoimpl.ExtensionFields
+
+	Name  *string `protobuf:"bytes,1,opt,name=name" json:"name,omitempty"`
+	Count *int32  `protobuf:"varint,2,opt,name=count,def=10" json:"count,omitempty"`
+}
+
+// Default values for LegacyMessage fields.
+const (
+	Default_LegacyMessage_Count = int32(10)
+)
+
+func (x *LegacyMessage) Reset() {
+	*x = LegacyMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testpb_legacy_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *LegacyMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LegacyMessage) ProtoMessage() {}
+
+func (x *LegacyMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_testpb_legacy_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LegacyMessage.ProtoReflect.Descriptor instead.
+func (*LegacyMessage) Descriptor() ([]byte, []int) {
+	return file_testpb_legacy_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *LegacyMessage) GetName() string {
+	if x != nil && x.Name != nil {
+		return *x.Name
+	}
+	return ""
+}
+
+func (x *LegacyMessage) GetCount() int32 {
+	if x != nil && x.Count != nil {
+		return *x.Count
+	}
+	return Default_LegacyMessage_Count
+}
+
+var file_testpb_legacy_proto_extTypes = []protoimpl.ExtensionInfo{
+	{
+		ExtendedType:  (*LegacyMessage)(nil),
+		ExtensionType: (*string)(nil),
+		Field:         100,
+		Name:          "com.github.zoncoen.querygo.extractor.protobuf.ext_name",
+		Tag:           "bytes,100,opt,name=ext_name",
+		Filename:      "testpb/legacy.proto",
+	},
+	{
+		ExtendedType:  (*LegacyMessage)(nil),
+		ExtensionType: (*LegacyMessage)(nil),
+		Field:         101,
+		Name:          "com.github.zoncoen.querygo.extractor.protobuf.ext_message",
+		Tag:           "bytes,101,opt,name=ext_message",
+		Filename:      "testpb/legacy.proto",
+	},
+	{
+		ExtendedType:  (*LegacyMessage)(nil),
+		ExtensionType: ([]int32)(nil),
+		Field:         102,
+		Name:          "com.github.zoncoen.querygo.extractor.protobuf.ext_numbers",
+		Tag:           "varint,102,rep,name=ext_numbers",
+		Filename:      "testpb/legacy.proto",
+	},
+	{
+		ExtendedType:  (*LegacyMessage)(nil),
+		ExtensionType: (*LegacyStatus)(nil),
+		Field:         103,
+		Name:          "com.github.zoncoen.querygo.extractor.protobuf.ext_status",
+		Tag:           "varint,103,opt,name=ext_status,enum=com.github.zoncoen.querygo.extractor.protobuf.LegacyStatus",
+		Filename:      "testpb/legacy.proto",
+	},
+}
+
+// Extension fields to LegacyMessage.
+var (
+	// optional string ext_name = 100;
+	E_ExtName = &file_testpb_legacy_proto_extTypes[0]
+	// optional com.github.zoncoen.querygo.extractor.protobuf.LegacyMessage ext_message = 101;
+	E_ExtMessage = &file_testpb_legacy_proto_extTypes[1]
+	// repeated int32 ext_numbers = 102;
+	E_ExtNumbers = &file_testpb_legacy_proto_extTypes[2]
+	// optional com.github.zoncoen.querygo.extractor.protobuf.LegacyStatus ext_status = 103;
+	E_ExtStatus = &file_testpb_legacy_proto_extTypes[3]
+)
+
+var File_testpb_legacy_proto protoreflect.FileDescriptor
+
+var file_testpb_legacy_proto_rawDesc = []byte{
+	0x0a, 0x13, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x2f, 0x6c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2d, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67,
+	0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x22, 0x44, 0x0a, 0x0d, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x18, 0x0a, 0x05, 0x63, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x05, 0x3a, 0x02, 0x31, 0x30, 0x52, 0x05, 0x63, 0x6f,
+	0x75, 0x6e, 0x74, 0x2a, 0x05, 0x08, 0x64, 0x10, 0xc8, 0x01, 0x2a, 0x3f, 0x0a, 0x0c, 0x4c, 0x65,
+	0x67, 0x61, 0x63, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x19, 0x0a, 0x15, 0x4c, 0x45,
+	0x47, 0x41, 0x43, 0x59, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x00, 0x12, 0x14, 0x0a, 0x10, 0x4c, 0x45, 0x47, 0x41, 0x43, 0x59, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x4f, 0x4b, 0x10, 0x01, 0x3a, 0x57, 0x0a, 0x08, 0x65,
+	0x78, 0x74, 0x5f, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x3c, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69,
+	0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65,
+	0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x64, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x65, 0x78, 0x74,
+	0x4e, 0x61, 0x6d, 0x65, 0x3a, 0x9b, 0x01, 0x0a, 0x0b, 0x65, 0x78, 0x74, 0x5f, 0x6d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x3c, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67,
+	0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x18, 0x65, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3c, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x4d,
+	0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x3a, 0x5d, 0x0a, 0x0b, 0x65, 0x78, 0x74, 0x5f, 0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x73, 0x12, 0x3c, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a,
+	0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65,
+	0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18,
+	0x66, 0x20, 0x03, 0x28, 0x05, 0x52, 0x0a, 0x65, 0x78, 0x74, 0x4e, 0x75, 0x6d, 0x62, 0x65, 0x72,
+	0x73, 0x3a, 0x98, 0x01, 0x0a, 0x0a, 0x65, 0x78, 0x74, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73,
+	0x12, 0x3c, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f,
+	0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x18, 0x67,
+	0x20, 0x01, 0x28, 0x0e, 0x32, 0x3b, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67,
+	0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x65, 0x67, 0x61, 0x63, 0x79, 0x53, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x52, 0x09, 0x65, 0x78, 0x74, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x42, 0x4b, 0x5a, 0x49,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x7a, 0x6f, 0x6e, 0x63, 0x6f,
+	0x65, 0x6e, 0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2d, 0x67, 0x6f, 0x2f, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x74, 0x65, 0x73, 0x74,
+	0x70, 0x62, 0x3b, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62,
+}
+
+var (
+	file_testpb_legacy_proto_rawDescOnce sync.Once
+	file_testpb_legacy_proto_rawDescData = file_testpb_legacy_proto_rawDesc
+)
+
+func file_testpb_legacy_proto_rawDescGZIP() []byte {
+	file_testpb_legacy_proto_rawDescOnce.Do(func() {
+		file_testpb_legacy_proto_rawDescData = protoimpl.X.CompressGZIP(file_testpb_legacy_proto_rawDescData)
+	})
+	return file_testpb_legacy_proto_rawDescData
+}
+
+var file_testpb_legacy_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_testpb_legacy_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_testpb_legacy_proto_goTypes = []interface{}{
+	(LegacyStatus)(0),     // 0: com.github.zoncoen.querygo.extractor.protobuf.LegacyStatus
+	(*LegacyMessage)(nil), // 1: com.github.zoncoen.querygo.extractor.protobuf.LegacyMessage
+}
+var file_testpb_legacy_proto_depIdxs = []int32{
+	1, // 0: com.github.zoncoen.querygo.extractor.protobuf.ext_name:extendee -> com.github.zoncoen.querygo.extractor.protobuf.LegacyMessage
+	1, // 1: com.github.zoncoen.querygo.extractor.protobuf.ext_message:extendee -> com.github.zoncoen.querygo.extractor.protobuf.LegacyMessage
+	1, // 2: com.github.zoncoen.querygo.extractor.protobuf.ext_numbers:extendee -> com.github.zoncoen.querygo.extractor.protobuf.LegacyMessage
+	1, // 3: com.github.zoncoen.querygo.extractor.protobuf.ext_status:extendee -> com.github.zoncoen.querygo.extractor.protobuf.LegacyMessage
+	1, // 4: com.github.zoncoen.querygo.extractor.protobuf.ext_message:type_name -> com.github.zoncoen.querygo.extractor.protobuf.LegacyMessage
+	0, // 5: com.github.zoncoen.querygo.extractor.protobuf.ext_status:type_name -> com.github.zoncoen.querygo.extractor.protobuf.LegacyStatus
+	6, // [6:6] is the sub-list for method output_type
+	6, // [6:6] is the sub-list for method input_type
+	4, // [4:6] is the sub-list for extension type_name
+	0, // [0:4] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_testpb_legacy_proto_init() }
+func file_testpb_legacy_proto_init() {
+	if File_testpb_legacy_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_testpb_legacy_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*LegacyMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			case 3:
+				return &v.extensionFields
+			default:
+				return nil
+			}
+		}
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_testpb_legacy_proto_rawDesc,
+			NumEnums:      1,
+			NumMessages:   1,
+			NumExtensions: 4,
+			NumServices:   0,
+		},
+		GoTypes:           file_testpb_legacy_proto_goTypes,
+		DependencyIndexes: file_testpb_legacy_proto_depIdxs,
+		EnumInfos:         file_testpb_legacy_proto_enumTypes,
+		MessageInfos:      file_testpb_legacy_proto_msgTypes,
+		ExtensionInfos:    file_testpb_legacy_proto_extTypes,
+	}.Build()
+	File_testpb_legacy_proto = out.File
+	file_testpb_legacy_proto_rawDesc = nil
+	file_testpb_legacy_proto_goTypes = nil
+	file_testpb_legacy_proto_depIdxs = nil
+}
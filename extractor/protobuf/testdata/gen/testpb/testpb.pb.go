@@ -9,6 +9,10 @@ package testpb
 import (
 	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
 	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	durationpb "google.golang.org/protobuf/types/known/durationpb"
+	structpb "google.golang.org/protobuf/types/known/structpb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	wrapperspb "google.golang.org/protobuf/types/known/wrapperspb"
 	reflect "reflect"
 	sync "sync"
 )
@@ -20,6 +24,55 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+type Status int32
+
+const (
+	Status_STATUS_UNSPECIFIED Status = 0
+	Status_STATUS_ACTIVE      Status = 1
+	Status_STATUS_INACTIVE    Status = 2
+)
+
+// Enum value maps for Status.
+var (
+	Status_name = map[int32]string{
+		0: "STATUS_UNSPECIFIED",
+		1: "STATUS_ACTIVE",
+		2: "STATUS_INACTIVE",
+	}
+	Status_value = map[string]int32{
+		"STATUS_UNSPECIFIED": 0,
+		"STATUS_ACTIVE":      1,
+		"STATUS_INACTIVE":    2,
+	}
+)
+
+func (x Status) Enum() *Status {
+	p := new(Status)
+	*p = x
+	return p
+}
+
+func (x Status) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (Status) Descriptor() protoreflect.EnumDescriptor {
+	return file_testpb_testpb_proto_enumTypes[0].Descriptor()
+}
+
+func (Status) Type() protoreflect.EnumType {
+	return &file_testpb_testpb_proto_enumTypes[0]
+}
+
+func (x Status) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use Status.Descriptor instead.
+func (Status) EnumDescriptor() ([]byte, []int) {
+	return file_testpb_testpb_proto_rawDescGZIP(), []int{0}
+}
+
 type OneofMessage struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -101,6 +154,334 @@ func (*OneofMessage_A_) isOneofMessage_Value() {}
 
 func (*OneofMessage_B_) isOneofMessage_Value() {}
 
+type WellKnownTypesMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	StringValue  *wrapperspb.StringValue           `protobuf:"bytes,1,opt,name=string_value,json=stringValue,proto3" json:"string_value,omitempty"`
+	Int64Value   *wrapperspb.Int64Value            `protobuf:"bytes,2,opt,name=int64_value,json=int64Value,proto3" json:"int64_value,omitempty"`
+	BoolValue    *wrapperspb.BoolValue             `protobuf:"bytes,3,opt,name=bool_value,json=boolValue,proto3" json:"bool_value,omitempty"`
+	Struct       *structpb.Struct                  `protobuf:"bytes,4,opt,name=struct,proto3" json:"struct,omitempty"`
+	ListValue    *structpb.ListValue               `protobuf:"bytes,5,opt,name=list_value,json=listValue,proto3" json:"list_value,omitempty"`
+	Value        *structpb.Value                   `protobuf:"bytes,6,opt,name=value,proto3" json:"value,omitempty"`
+	Timestamp    *timestamppb.Timestamp            `protobuf:"bytes,7,opt,name=timestamp,proto3" json:"timestamp,omitempty"`
+	Duration     *durationpb.Duration              `protobuf:"bytes,8,opt,name=duration,proto3" json:"duration,omitempty"`
+	StringValues []*wrapperspb.StringValue         `protobuf:"bytes,9,rep,name=string_values,json=stringValues,proto3" json:"string_values,omitempty"`
+	Timestamps   map[string]*timestamppb.Timestamp `protobuf:"bytes,10,rep,name=timestamps,proto3" json:"timestamps,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *WellKnownTypesMessage) Reset() {
+	*x = WellKnownTypesMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testpb_testpb_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *WellKnownTypesMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WellKnownTypesMessage) ProtoMessage() {}
+
+func (x *WellKnownTypesMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_testpb_testpb_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WellKnownTypesMessage.ProtoReflect.Descriptor instead.
+func (*WellKnownTypesMessage) Descriptor() ([]byte, []int) {
+	return file_testpb_testpb_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *WellKnownTypesMessage) GetStringValue() *wrapperspb.StringValue {
+	if x != nil {
+		return x.StringValue
+	}
+	return nil
+}
+
+func (x *WellKnownTypesMessage) GetInt64Value() *wrapperspb.Int64Value {
+	if x != nil {
+		return x.Int64Value
+	}
+	return nil
+}
+
+func (x *WellKnownTypesMessage) GetBoolValue() *wrapperspb.BoolValue {
+	if x != nil {
+		return x.BoolValue
+	}
+	return nil
+}
+
+func (x *WellKnownTypesMessage) GetStruct() *structpb.Struct {
+	if x != nil {
+		return x.Struct
+	}
+	return nil
+}
+
+func (x *WellKnownTypesMessage) GetListValue() *structpb.ListValue {
+	if x != nil {
+		return x.ListValue
+	}
+	return nil
+}
+
+func (x *WellKnownTypesMessage) GetValue() *structpb.Value {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *WellKnownTypesMessage) GetTimestamp() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamp
+	}
+	return nil
+}
+
+func (x *WellKnownTypesMessage) GetDuration() *durationpb.Duration {
+	if x != nil {
+		return x.Duration
+	}
+	return nil
+}
+
+func (x *WellKnownTypesMessage) GetStringValues() []*wrapperspb.StringValue {
+	if x != nil {
+		return x.StringValues
+	}
+	return nil
+}
+
+func (x *WellKnownTypesMessage) GetTimestamps() map[string]*timestamppb.Timestamp {
+	if x != nil {
+		return x.Timestamps
+	}
+	return nil
+}
+
+type Message struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name       string                     `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count      int32                      `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	Nested     *Message_Nested            `protobuf:"bytes,3,opt,name=nested,proto3" json:"nested,omitempty"`
+	NestedList []*Message_Nested          `protobuf:"bytes,4,rep,name=nested_list,json=nestedList,proto3" json:"nested_list,omitempty"`
+	NestedMap  map[string]*Message_Nested `protobuf:"bytes,5,rep,name=nested_map,json=nestedMap,proto3" json:"nested_map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+	Status     Status                     `protobuf:"varint,6,opt,name=status,proto3,enum=com.github.zoncoen.querygo.extractor.protobuf.Status" json:"status,omitempty"`
+	Statuses   []Status                   `protobuf:"varint,7,rep,packed,name=statuses,proto3,enum=com.github.zoncoen.querygo.extractor.protobuf.Status" json:"statuses,omitempty"`
+	StatusMap  map[string]Status          `protobuf:"bytes,8,rep,name=status_map,json=statusMap,proto3" json:"status_map,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3,enum=com.github.zoncoen.querygo.extractor.protobuf.Status"`
+	// Types that are assignable to Payload:
+	//
+	//	*Message_Text
+	//	*Message_NestedPayload
+	Payload        isMessage_Payload `protobuf_oneof:"payload"`
+	OptionalCount  *int32            `protobuf:"varint,11,opt,name=optional_count,json=optionalCount,proto3,oneof" json:"optional_count,omitempty"`
+	OptionalStatus *Status           `protobuf:"varint,12,opt,name=optional_status,json=optionalStatus,proto3,enum=com.github.zoncoen.querygo.extractor.protobuf.Status,oneof" json:"optional_status,omitempty"`
+	Numbers        []int32           `protobuf:"varint,13,rep,packed,name=numbers,proto3" json:"numbers,omitempty"`
+	Delta          int64             `protobuf:"zigzag64,14,opt,name=delta,proto3" json:"delta,omitempty"`
+	Ratio          float64           `protobuf:"fixed64,15,opt,name=ratio,proto3" json:"ratio,omitempty"`
+	Checksum       uint32            `protobuf:"fixed32,16,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	Data           []byte            `protobuf:"bytes,17,opt,name=data,proto3" json:"data,omitempty"`
+	Labels         map[int32]string  `protobuf:"bytes,18,rep,name=labels,proto3" json:"labels,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Message) Reset() {
+	*x = Message{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testpb_testpb_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Message) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message) ProtoMessage() {}
+
+func (x *Message) ProtoReflect() protoreflect.Message {
+	mi := &file_testpb_testpb_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message.ProtoReflect.Descriptor instead.
+func (*Message) Descriptor() ([]byte, []int) {
+	return file_testpb_testpb_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *Message) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Message) GetCount() int32 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+func (x *Message) GetNested() *Message_Nested {
+	if x != nil {
+		return x.Nested
+	}
+	return nil
+}
+
+func (x *Message) GetNestedList() []*Message_Nested {
+	if x != nil {
+		return x.NestedList
+	}
+	return nil
+}
+
+func (x *Message) GetNestedMap() map[string]*Message_Nested {
+	if x != nil {
+		return x.NestedMap
+	}
+	return nil
+}
+
+func (x *Message) GetStatus() Status {
+	if x != nil {
+		return x.Status
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *Message) GetStatuses() []Status {
+	if x != nil {
+		return x.Statuses
+	}
+	return nil
+}
+
+func (x *Message) GetStatusMap() map[string]Status {
+	if x != nil {
+		return x.StatusMap
+	}
+	return nil
+}
+
+func (m *Message) GetPayload() isMessage_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *Message) GetText() string {
+	if x, ok := x.GetPayload().(*Message_Text); ok {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *Message) GetNestedPayload() *Message_Nested {
+	if x, ok := x.GetPayload().(*Message_NestedPayload); ok {
+		return x.NestedPayload
+	}
+	return nil
+}
+
+func (x *Message) GetOptionalCount() int32 {
+	if x != nil && x.OptionalCount != nil {
+		return *x.OptionalCount
+	}
+	return 0
+}
+
+func (x *Message) GetOptionalStatus() Status {
+	if x != nil && x.OptionalStatus != nil {
+		return *x.OptionalStatus
+	}
+	return Status_STATUS_UNSPECIFIED
+}
+
+func (x *Message) GetNumbers() []int32 {
+	if x != nil {
+		return x.Numbers
+	}
+	return nil
+}
+
+func (x *Message) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+func (x *Message) GetRatio() float64 {
+	if x != nil {
+		return x.Ratio
+	}
+	return 0
+}
+
+func (x *Message) GetChecksum() uint32 {
+	if x != nil {
+		return x.Checksum
+	}
+	return 0
+}
+
+func (x *Message) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *Message) GetLabels() map[int32]string {
+	if x != nil {
+		return x.Labels
+	}
+	return nil
+}
+
+type isMessage_Payload interface {
+	isMessage_Payload()
+}
+
+type Message_Text struct {
+	Text string `protobuf:"bytes,9,opt,name=text,proto3,oneof"`
+}
+
+type Message_NestedPayload struct {
+	NestedPayload *Message_Nested `protobuf:"bytes,10,opt,name=nested_payload,json=nestedPayload,proto3,oneof"`
+}
+
+func (*Message_Text) isMessage_Payload() {}
+
+func (*Message_NestedPayload) isMessage_Payload() {}
+
 type OneofMessage_A struct {
 	state         protoimpl.MessageState
 	sizeCache     protoimpl.SizeCache
@@ -112,7 +493,7 @@ type OneofMessage_A struct {
 func (x *OneofMessage_A) Reset() {
 	*x = OneofMessage_A{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testpb_testpb_proto_msgTypes[1]
+		mi := &file_testpb_testpb_proto_msgTypes[3]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -125,7 +506,7 @@ func (x *OneofMessage_A) String() string {
 func (*OneofMessage_A) ProtoMessage() {}
 
 func (x *OneofMessage_A) ProtoReflect() protoreflect.Message {
-	mi := &file_testpb_testpb_proto_msgTypes[1]
+	mi := &file_testpb_testpb_proto_msgTypes[3]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -159,7 +540,7 @@ type OneofMessage_B struct {
 func (x *OneofMessage_B) Reset() {
 	*x = OneofMessage_B{}
 	if protoimpl.UnsafeEnabled {
-		mi := &file_testpb_testpb_proto_msgTypes[2]
+		mi := &file_testpb_testpb_proto_msgTypes[4]
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		ms.StoreMessageInfo(mi)
 	}
@@ -172,7 +553,7 @@ func (x *OneofMessage_B) String() string {
 func (*OneofMessage_B) ProtoMessage() {}
 
 func (x *OneofMessage_B) ProtoReflect() protoreflect.Message {
-	mi := &file_testpb_testpb_proto_msgTypes[2]
+	mi := &file_testpb_testpb_proto_msgTypes[4]
 	if protoimpl.UnsafeEnabled && x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -195,6 +576,61 @@ func (x *OneofMessage_B) GetBarValue() string {
 	return ""
 }
 
+type Message_Nested struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Value string          `protobuf:"bytes,1,opt,name=value,proto3" json:"value,omitempty"`
+	Child *Message_Nested `protobuf:"bytes,2,opt,name=child,proto3" json:"child,omitempty"`
+}
+
+func (x *Message_Nested) Reset() {
+	*x = Message_Nested{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_testpb_testpb_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Message_Nested) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Message_Nested) ProtoMessage() {}
+
+func (x *Message_Nested) ProtoReflect() protoreflect.Message {
+	mi := &file_testpb_testpb_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Message_Nested.ProtoReflect.Descriptor instead.
+func (*Message_Nested) Descriptor() ([]byte, []int) {
+	return file_testpb_testpb_proto_rawDescGZIP(), []int{2, 3}
+}
+
+func (x *Message_Nested) GetValue() string {
+	if x != nil {
+		return x.Value
+	}
+	return ""
+}
+
+func (x *Message_Nested) GetChild() *Message_Nested {
+	if x != nil {
+		return x.Child
+	}
+	return nil
+}
+
 var File_testpb_testpb_proto protoreflect.FileDescriptor
 
 var file_testpb_testpb_proto_rawDesc = []byte{
@@ -202,28 +638,185 @@ var file_testpb_testpb_proto_rawDesc = []byte{
 	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x2d, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
 	0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67,
 	0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
-	0x6f, 0x62, 0x75, 0x66, 0x22, 0xf9, 0x01, 0x0a, 0x0c, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x4d, 0x65,
-	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x4d, 0x0a, 0x01, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b,
-	0x32, 0x3d, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f,
-	0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78,
-	0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
-	0x2e, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x41, 0x48,
-	0x00, 0x52, 0x01, 0x61, 0x12, 0x4d, 0x0a, 0x01, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x6f, 0x62, 0x75, 0x66, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x1a, 0x1c, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x1a, 0x1f, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2f, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x1a, 0x1e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2f, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2f, 0x77, 0x72, 0x61, 0x70, 0x70, 0x65, 0x72, 0x73, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x22, 0xf9, 0x01, 0x0a, 0x0c, 0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x4d, 0x65, 0x73,
+	0x73, 0x61, 0x67, 0x65, 0x12, 0x4d, 0x0a, 0x01, 0x61, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
 	0x3d, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e,
 	0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74,
 	0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e,
-	0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x42, 0x48, 0x00,
-	0x52, 0x01, 0x62, 0x1a, 0x20, 0x0a, 0x01, 0x41, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x6f, 0x6f, 0x5f,
-	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x6f, 0x6f,
-	0x56, 0x61, 0x6c, 0x75, 0x65, 0x1a, 0x20, 0x0a, 0x01, 0x42, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61,
-	0x72, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62,
-	0x61, 0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65,
-	0x42, 0x4b, 0x5a, 0x49, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x7a,
-	0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2d, 0x67, 0x6f, 0x2f,
-	0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
-	0x75, 0x66, 0x2f, 0x74, 0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x67, 0x65, 0x6e, 0x2f,
-	0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x3b, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70,
-	0x72, 0x6f, 0x74, 0x6f, 0x33,
+	0x4f, 0x6e, 0x65, 0x6f, 0x66, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x41, 0x48, 0x00,
+	0x52, 0x01, 0x61, 0x12, 0x4d, 0x0a, 0x01, 0x62, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3d,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63,
+	0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4f,
+	0x6e, 0x65, 0x6f, 0x66, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x42, 0x48, 0x00, 0x52,
+	0x01, 0x62, 0x1a, 0x20, 0x0a, 0x01, 0x41, 0x12, 0x1b, 0x0a, 0x09, 0x66, 0x6f, 0x6f, 0x5f, 0x76,
+	0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x66, 0x6f, 0x6f, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x1a, 0x20, 0x0a, 0x01, 0x42, 0x12, 0x1b, 0x0a, 0x09, 0x62, 0x61, 0x72,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x08, 0x62, 0x61,
+	0x72, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x42, 0x07, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x22,
+	0xf0, 0x05, 0x0a, 0x15, 0x57, 0x65, 0x6c, 0x6c, 0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x54, 0x79, 0x70,
+	0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x3f, 0x0a, 0x0c, 0x73, 0x74, 0x72,
+	0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0b, 0x73,
+	0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x3c, 0x0a, 0x0b, 0x69, 0x6e,
+	0x74, 0x36, 0x34, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x1b, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x49, 0x6e, 0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x0a, 0x69, 0x6e,
+	0x74, 0x36, 0x34, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x39, 0x0a, 0x0a, 0x62, 0x6f, 0x6f, 0x6c,
+	0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67,
+	0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x42,
+	0x6f, 0x6f, 0x6c, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x62, 0x6f, 0x6f, 0x6c, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x12, 0x2f, 0x0a, 0x06, 0x73, 0x74, 0x72, 0x75, 0x63, 0x74, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x75, 0x63, 0x74, 0x52, 0x06, 0x73, 0x74,
+	0x72, 0x75, 0x63, 0x74, 0x12, 0x39, 0x0a, 0x0a, 0x6c, 0x69, 0x73, 0x74, 0x5f, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c,
+	0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4c, 0x69, 0x73, 0x74, 0x56,
+	0x61, 0x6c, 0x75, 0x65, 0x52, 0x09, 0x6c, 0x69, 0x73, 0x74, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x12,
+	0x2c, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x56, 0x61, 0x6c, 0x75, 0x65, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x38, 0x0a,
+	0x09, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x54, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x09, 0x74, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x12, 0x35, 0x0a, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x19, 0x2e, 0x67, 0x6f, 0x6f, 0x67,
+	0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x44, 0x75, 0x72, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x52, 0x08, 0x64, 0x75, 0x72, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x41,
+	0x0a, 0x0d, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x5f, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x73, 0x18,
+	0x09, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x67, 0x6f, 0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70,
+	0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61,
+	0x6c, 0x75, 0x65, 0x52, 0x0c, 0x73, 0x74, 0x72, 0x69, 0x6e, 0x67, 0x56, 0x61, 0x6c, 0x75, 0x65,
+	0x73, 0x12, 0x74, 0x0a, 0x0a, 0x74, 0x69, 0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x18,
+	0x0a, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x54, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x57, 0x65, 0x6c, 0x6c, 0x4b, 0x6e, 0x6f, 0x77, 0x6e, 0x54,
+	0x79, 0x70, 0x65, 0x73, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x54, 0x69, 0x6d, 0x65,
+	0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x0a, 0x74, 0x69, 0x6d,
+	0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x73, 0x1a, 0x59, 0x0a, 0x0f, 0x54, 0x69, 0x6d, 0x65, 0x73,
+	0x74, 0x61, 0x6d, 0x70, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x30, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x67, 0x6f,
+	0x6f, 0x67, 0x6c, 0x65, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x54, 0x69,
+	0x6d, 0x65, 0x73, 0x74, 0x61, 0x6d, 0x70, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02,
+	0x38, 0x01, 0x22, 0x8d, 0x0c, 0x0a, 0x07, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x12,
+	0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6e, 0x61,
+	0x6d, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x05, 0x52, 0x05, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x55, 0x0a, 0x06, 0x6e, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67,
+	0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75,
+	0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e,
+	0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x06, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x12,
+	0x5e, 0x0a, 0x0b, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x6c, 0x69, 0x73, 0x74, 0x18, 0x04,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75,
+	0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67,
+	0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74,
+	0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4e, 0x65, 0x73,
+	0x74, 0x65, 0x64, 0x52, 0x0a, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4c, 0x69, 0x73, 0x74, 0x12,
+	0x64, 0x0a, 0x0a, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x05, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x45, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62,
+	0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f,
+	0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x09, 0x6e, 0x65, 0x73, 0x74,
+	0x65, 0x64, 0x4d, 0x61, 0x70, 0x12, 0x4d, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18,
+	0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x35, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68,
+	0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79,
+	0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f,
+	0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x06, 0x73, 0x74,
+	0x61, 0x74, 0x75, 0x73, 0x12, 0x51, 0x0a, 0x08, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73,
+	0x18, 0x07, 0x20, 0x03, 0x28, 0x0e, 0x32, 0x35, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74,
+	0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72,
+	0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72,
+	0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x08, 0x73,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x65, 0x73, 0x12, 0x64, 0x0a, 0x0a, 0x73, 0x74, 0x61, 0x74, 0x75,
+	0x73, 0x5f, 0x6d, 0x61, 0x70, 0x18, 0x08, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x45, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e,
+	0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74,
+	0x72, 0x79, 0x52, 0x09, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x61, 0x70, 0x12, 0x14, 0x0a,
+	0x04, 0x74, 0x65, 0x78, 0x74, 0x18, 0x09, 0x20, 0x01, 0x28, 0x09, 0x48, 0x00, 0x52, 0x04, 0x74,
+	0x65, 0x78, 0x74, 0x12, 0x66, 0x0a, 0x0e, 0x6e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x5f, 0x70, 0x61,
+	0x79, 0x6c, 0x6f, 0x61, 0x64, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x63, 0x6f,
+	0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e,
+	0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74,
+	0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73,
+	0x61, 0x67, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x48, 0x00, 0x52, 0x0d, 0x6e, 0x65,
+	0x73, 0x74, 0x65, 0x64, 0x50, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x12, 0x2a, 0x0a, 0x0e, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x0b, 0x20,
+	0x01, 0x28, 0x05, 0x48, 0x01, 0x52, 0x0d, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x43,
+	0x6f, 0x75, 0x6e, 0x74, 0x88, 0x01, 0x01, 0x12, 0x63, 0x0a, 0x0f, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x74, 0x75, 0x73, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0e,
+	0x32, 0x35, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f,
+	0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78,
+	0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66,
+	0x2e, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x48, 0x02, 0x52, 0x0e, 0x6f, 0x70, 0x74, 0x69, 0x6f,
+	0x6e, 0x61, 0x6c, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x88, 0x01, 0x01, 0x12, 0x18, 0x0a, 0x07,
+	0x6e, 0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x18, 0x0d, 0x20, 0x03, 0x28, 0x05, 0x52, 0x07, 0x6e,
+	0x75, 0x6d, 0x62, 0x65, 0x72, 0x73, 0x12, 0x14, 0x0a, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x18,
+	0x0e, 0x20, 0x01, 0x28, 0x12, 0x52, 0x05, 0x64, 0x65, 0x6c, 0x74, 0x61, 0x12, 0x14, 0x0a, 0x05,
+	0x72, 0x61, 0x74, 0x69, 0x6f, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x01, 0x52, 0x05, 0x72, 0x61, 0x74,
+	0x69, 0x6f, 0x12, 0x1a, 0x0a, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x18, 0x10,
+	0x20, 0x01, 0x28, 0x07, 0x52, 0x08, 0x63, 0x68, 0x65, 0x63, 0x6b, 0x73, 0x75, 0x6d, 0x12, 0x12,
+	0x0a, 0x04, 0x64, 0x61, 0x74, 0x61, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0c, 0x52, 0x04, 0x64, 0x61,
+	0x74, 0x61, 0x12, 0x5a, 0x0a, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x18, 0x12, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x42, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e,
+	0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e,
+	0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62,
+	0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4c, 0x61, 0x62, 0x65, 0x6c,
+	0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x06, 0x6c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x1a, 0x7b,
+	0x0a, 0x0e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79,
+	0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b,
+	0x65, 0x79, 0x12, 0x53, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x3d, 0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a,
+	0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65,
+	0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75,
+	0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x73, 0x0a, 0x0e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x4d, 0x61, 0x70, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a,
+	0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12,
+	0x4b, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x35,
+	0x2e, 0x63, 0x6f, 0x6d, 0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63,
+	0x6f, 0x65, 0x6e, 0x2e, 0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x53,
+	0x74, 0x61, 0x74, 0x75, 0x73, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01,
+	0x1a, 0x39, 0x0a, 0x0b, 0x4c, 0x61, 0x62, 0x65, 0x6c, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12,
+	0x10, 0x0a, 0x03, 0x6b, 0x65, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x05, 0x52, 0x03, 0x6b, 0x65,
+	0x79, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x1a, 0x73, 0x0a, 0x06, 0x4e,
+	0x65, 0x73, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c, 0x75, 0x65, 0x12, 0x53, 0x0a, 0x05, 0x63,
+	0x68, 0x69, 0x6c, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x3d, 0x2e, 0x63, 0x6f, 0x6d,
+	0x2e, 0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x7a, 0x6f, 0x6e, 0x63, 0x6f, 0x65, 0x6e, 0x2e,
+	0x71, 0x75, 0x65, 0x72, 0x79, 0x67, 0x6f, 0x2e, 0x65, 0x78, 0x74, 0x72, 0x61, 0x63, 0x74, 0x6f,
+	0x72, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2e, 0x4d, 0x65, 0x73, 0x73, 0x61,
+	0x67, 0x65, 0x2e, 0x4e, 0x65, 0x73, 0x74, 0x65, 0x64, 0x52, 0x05, 0x63, 0x68, 0x69, 0x6c, 0x64,
+	0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61, 0x64, 0x42, 0x11, 0x0a, 0x0f, 0x5f,
+	0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x63, 0x6f, 0x75, 0x6e, 0x74, 0x42, 0x12,
+	0x0a, 0x10, 0x5f, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x61, 0x6c, 0x5f, 0x73, 0x74, 0x61, 0x74,
+	0x75, 0x73, 0x2a, 0x48, 0x0a, 0x06, 0x53, 0x74, 0x61, 0x74, 0x75, 0x73, 0x12, 0x16, 0x0a, 0x12,
+	0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x43, 0x49, 0x46, 0x49,
+	0x45, 0x44, 0x10, 0x00, 0x12, 0x11, 0x0a, 0x0d, 0x53, 0x54, 0x41, 0x54, 0x55, 0x53, 0x5f, 0x41,
+	0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x01, 0x12, 0x13, 0x0a, 0x0f, 0x53, 0x54, 0x41, 0x54, 0x55,
+	0x53, 0x5f, 0x49, 0x4e, 0x41, 0x43, 0x54, 0x49, 0x56, 0x45, 0x10, 0x02, 0x42, 0x4b, 0x5a, 0x49,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x7a, 0x6f, 0x6e, 0x63, 0x6f,
+	0x65, 0x6e, 0x2f, 0x71, 0x75, 0x65, 0x72, 0x79, 0x2d, 0x67, 0x6f, 0x2f, 0x65, 0x78, 0x74, 0x72,
+	0x61, 0x63, 0x74, 0x6f, 0x72, 0x2f, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x62, 0x75, 0x66, 0x2f, 0x74,
+	0x65, 0x73, 0x74, 0x64, 0x61, 0x74, 0x61, 0x2f, 0x67, 0x65, 0x6e, 0x2f, 0x74, 0x65, 0x73, 0x74,
+	0x70, 0x62, 0x3b, 0x74, 0x65, 0x73, 0x74, 0x70, 0x62, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f,
+	0x33,
 }
 
 var (
@@ -238,20 +831,60 @@ func file_testpb_testpb_proto_rawDescGZIP() []byte {
 	return file_testpb_testpb_proto_rawDescData
 }
 
-var file_testpb_testpb_proto_msgTypes = make([]protoimpl.MessageInfo, 3)
+var file_testpb_testpb_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
+var file_testpb_testpb_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_testpb_testpb_proto_goTypes = []interface{}{
-	(*OneofMessage)(nil),   // 0: com.github.zoncoen.querygo.extractor.protobuf.OneofMessage
-	(*OneofMessage_A)(nil), // 1: com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.A
-	(*OneofMessage_B)(nil), // 2: com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.B
+	(Status)(0),                    // 0: com.github.zoncoen.querygo.extractor.protobuf.Status
+	(*OneofMessage)(nil),           // 1: com.github.zoncoen.querygo.extractor.protobuf.OneofMessage
+	(*WellKnownTypesMessage)(nil),  // 2: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage
+	(*Message)(nil),                // 3: com.github.zoncoen.querygo.extractor.protobuf.Message
+	(*OneofMessage_A)(nil),         // 4: com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.A
+	(*OneofMessage_B)(nil),         // 5: com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.B
+	nil,                            // 6: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.TimestampsEntry
+	nil,                            // 7: com.github.zoncoen.querygo.extractor.protobuf.Message.NestedMapEntry
+	nil,                            // 8: com.github.zoncoen.querygo.extractor.protobuf.Message.StatusMapEntry
+	nil,                            // 9: com.github.zoncoen.querygo.extractor.protobuf.Message.LabelsEntry
+	(*Message_Nested)(nil),         // 10: com.github.zoncoen.querygo.extractor.protobuf.Message.Nested
+	(*wrapperspb.StringValue)(nil), // 11: google.protobuf.StringValue
+	(*wrapperspb.Int64Value)(nil),  // 12: google.protobuf.Int64Value
+	(*wrapperspb.BoolValue)(nil),   // 13: google.protobuf.BoolValue
+	(*structpb.Struct)(nil),        // 14: google.protobuf.Struct
+	(*structpb.ListValue)(nil),     // 15: google.protobuf.ListValue
+	(*structpb.Value)(nil),         // 16: google.protobuf.Value
+	(*timestamppb.Timestamp)(nil),  // 17: google.protobuf.Timestamp
+	(*durationpb.Duration)(nil),    // 18: google.protobuf.Duration
 }
 var file_testpb_testpb_proto_depIdxs = []int32{
-	1, // 0: com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.a:type_name -> com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.A
-	2, // 1: com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.b:type_name -> com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.B
-	2, // [2:2] is the sub-list for method output_type
-	2, // [2:2] is the sub-list for method input_type
-	2, // [2:2] is the sub-list for extension type_name
-	2, // [2:2] is the sub-list for extension extendee
-	0, // [0:2] is the sub-list for field type_name
+	4,  // 0: com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.a:type_name -> com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.A
+	5,  // 1: com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.b:type_name -> com.github.zoncoen.querygo.extractor.protobuf.OneofMessage.B
+	11, // 2: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.string_value:type_name -> google.protobuf.StringValue
+	12, // 3: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.int64_value:type_name -> google.protobuf.Int64Value
+	13, // 4: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.bool_value:type_name -> google.protobuf.BoolValue
+	14, // 5: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.struct:type_name -> google.protobuf.Struct
+	15, // 6: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.list_value:type_name -> google.protobuf.ListValue
+	16, // 7: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.value:type_name -> google.protobuf.Value
+	17, // 8: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.timestamp:type_name -> google.protobuf.Timestamp
+	18, // 9: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.duration:type_name -> google.protobuf.Duration
+	11, // 10: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.string_values:type_name -> google.protobuf.StringValue
+	6,  // 11: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.timestamps:type_name -> com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.TimestampsEntry
+	10, // 12: com.github.zoncoen.querygo.extractor.protobuf.Message.nested:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Message.Nested
+	10, // 13: com.github.zoncoen.querygo.extractor.protobuf.Message.nested_list:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Message.Nested
+	7,  // 14: com.github.zoncoen.querygo.extractor.protobuf.Message.nested_map:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Message.NestedMapEntry
+	0,  // 15: com.github.zoncoen.querygo.extractor.protobuf.Message.status:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Status
+	0,  // 16: com.github.zoncoen.querygo.extractor.protobuf.Message.statuses:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Status
+	8,  // 17: com.github.zoncoen.querygo.extractor.protobuf.Message.status_map:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Message.StatusMapEntry
+	10, // 18: com.github.zoncoen.querygo.extractor.protobuf.Message.nested_payload:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Message.Nested
+	0,  // 19: com.github.zoncoen.querygo.extractor.protobuf.Message.optional_status:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Status
+	9,  // 20: com.github.zoncoen.querygo.extractor.protobuf.Message.labels:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Message.LabelsEntry
+	17, // 21: com.github.zoncoen.querygo.extractor.protobuf.WellKnownTypesMessage.TimestampsEntry.value:type_name -> google.protobuf.Timestamp
+	10, // 22: com.github.zoncoen.querygo.extractor.protobuf.Message.NestedMapEntry.value:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Message.Nested
+	0,  // 23: com.github.zoncoen.querygo.extractor.protobuf.Message.StatusMapEntry.value:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Status
+	10, // 24: com.github.zoncoen.querygo.extractor.protobuf.Message.Nested.child:type_name -> com.github.zoncoen.querygo.extractor.protobuf.Message.Nested
+	25, // [25:25] is the sub-list for method output_type
+	25, // [25:25] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_testpb_testpb_proto_init() }
@@ -273,7 +906,7 @@ func file_testpb_testpb_proto_init() {
 			}
 		}
 		file_testpb_testpb_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
-			switch v := v.(*OneofMessage_A); i {
+			switch v := v.(*WellKnownTypesMessage); i {
 			case 0:
 				return &v.state
 			case 1:
@@ -285,6 +918,30 @@ func file_testpb_testpb_proto_init() {
 			}
 		}
 		file_testpb_testpb_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testpb_testpb_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*OneofMessage_A); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_testpb_testpb_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
 			switch v := v.(*OneofMessage_B); i {
 			case 0:
 				return &v.state
@@ -296,23 +953,40 @@ func file_testpb_testpb_proto_init() {
 				return nil
 			}
 		}
+		file_testpb_testpb_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Message_Nested); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
 	}
 	file_testpb_testpb_proto_msgTypes[0].OneofWrappers = []interface{}{
 		(*OneofMessage_A_)(nil),
 		(*OneofMessage_B_)(nil),
 	}
+	file_testpb_testpb_proto_msgTypes[2].OneofWrappers = []interface{}{
+		(*Message_Text)(nil),
+		(*Message_NestedPayload)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: file_testpb_testpb_proto_rawDesc,
-			NumEnums:      0,
-			NumMessages:   3,
+			NumEnums:      1,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
 		GoTypes:           file_testpb_testpb_proto_goTypes,
 		DependencyIndexes: file_testpb_testpb_proto_depIdxs,
+		EnumInfos:         file_testpb_testpb_proto_enumTypes,
 		MessageInfos:      file_testpb_testpb_proto_msgTypes,
 	}.Build()
 	File_testpb_testpb_proto = out.File
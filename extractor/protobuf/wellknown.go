@@ -0,0 +1,94 @@
+package protobuf
+
+import (
+	"reflect"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+)
+
+type wellKnownType struct {
+	// typ is the Go type that a message of the type is unwrapped to.
+	typ    reflect.Type
+	unwrap func(proto.Message) any
+}
+
+func newWellKnownType[M proto.Message, T any](f func(M) T) wellKnownType {
+	return wellKnownType{
+		typ: reflect.TypeFor[T](),
+		unwrap: func(m proto.Message) any {
+			return f(m.(M))
+		},
+	}
+}
+
+var wellKnownTypes = map[reflect.Type]wellKnownType{
+	reflect.TypeFor[*wrapperspb.DoubleValue](): newWellKnownType((*wrapperspb.DoubleValue).GetValue),
+	reflect.TypeFor[*wrapperspb.FloatValue]():  newWellKnownType((*wrapperspb.FloatValue).GetValue),
+	reflect.TypeFor[*wrapperspb.Int64Value]():  newWellKnownType((*wrapperspb.Int64Value).GetValue),
+	reflect.TypeFor[*wrapperspb.UInt64Value](): newWellKnownType((*wrapperspb.UInt64Value).GetValue),
+	reflect.TypeFor[*wrapperspb.Int32Value]():  newWellKnownType((*wrapperspb.Int32Value).GetValue),
+	reflect.TypeFor[*wrapperspb.UInt32Value](): newWellKnownType((*wrapperspb.UInt32Value).GetValue),
+	reflect.TypeFor[*wrapperspb.BoolValue]():   newWellKnownType((*wrapperspb.BoolValue).GetValue),
+	reflect.TypeFor[*wrapperspb.StringValue](): newWellKnownType((*wrapperspb.StringValue).GetValue),
+	reflect.TypeFor[*wrapperspb.BytesValue]():  newWellKnownType((*wrapperspb.BytesValue).GetValue),
+	reflect.TypeFor[*structpb.Struct]():        newWellKnownType((*structpb.Struct).AsMap),
+	reflect.TypeFor[*structpb.ListValue]():     newWellKnownType((*structpb.ListValue).AsSlice),
+	reflect.TypeFor[*structpb.Value]():         newWellKnownType((*structpb.Value).AsInterface),
+	reflect.TypeFor[*timestamppb.Timestamp]():  newWellKnownType((*timestamppb.Timestamp).AsTime),
+	reflect.TypeFor[*durationpb.Duration]():    newWellKnownType((*durationpb.Duration).AsDuration),
+}
+
+// unwrapWellKnownType converts a well-known type message, or a slice or map
+// of them, to its natural Go value. Any other value is returned as is.
+func unwrapWellKnownType(v reflect.Value) reflect.Value {
+	if !v.IsValid() || !v.CanInterface() {
+		return v
+	}
+	in := v
+	if v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	switch v.Kind() {
+	case reflect.Pointer:
+		if w, ok := wellKnownTypes[v.Type()]; ok {
+			return w.value(v)
+		}
+	case reflect.Slice:
+		if w, ok := wellKnownTypes[v.Type().Elem()]; ok {
+			s := reflect.MakeSlice(reflect.SliceOf(w.typ), v.Len(), v.Len())
+			for i := range v.Len() {
+				if x := w.value(v.Index(i)); x.IsValid() {
+					s.Index(i).Set(x)
+				}
+			}
+			return s
+		}
+	case reflect.Map:
+		if w, ok := wellKnownTypes[v.Type().Elem()]; ok {
+			m := reflect.MakeMapWithSize(reflect.MapOf(v.Type().Key(), w.typ), v.Len())
+			iter := v.MapRange()
+			for iter.Next() {
+				x := w.value(iter.Value())
+				if !x.IsValid() {
+					x = reflect.Zero(w.typ)
+				}
+				m.SetMapIndex(iter.Key(), x)
+			}
+			return m
+		}
+	}
+	return in
+}
+
+// value unwraps the message v. A nil message is unwrapped to the invalid
+// value, which Query.Extract reports as nil.
+func (w wellKnownType) value(v reflect.Value) reflect.Value {
+	if v.IsNil() {
+		return reflect.Value{}
+	}
+	return reflect.ValueOf(w.unwrap(v.Interface().(proto.Message)))
+}
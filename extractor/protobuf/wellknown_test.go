@@ -0,0 +1,183 @@
+package protobuf
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+	"google.golang.org/protobuf/types/known/wrapperspb"
+
+	testpb "github.com/zoncoen/query-go/extractor/protobuf/testdata/gen/testpb"
+	"github.com/zoncoen/query-go/v2"
+)
+
+func TestUnwrapWellKnownTypes(t *testing.T) {
+	now := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	s, err := structpb.NewStruct(map[string]any{
+		"a": map[string]any{
+			"b": []any{"x", 1.5, true, nil},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := &testpb.WellKnownTypesMessage{
+		StringValue: wrapperspb.String("aaa"),
+		Int64Value:  wrapperspb.Int64(10),
+		Struct:      s,
+		ListValue: &structpb.ListValue{
+			Values: []*structpb.Value{structpb.NewStringValue("first")},
+		},
+		Value:     structpb.NewNumberValue(2),
+		Timestamp: timestamppb.New(now),
+		Duration:  durationpb.New(3 * time.Second),
+		StringValues: []*wrapperspb.StringValue{
+			wrapperspb.String("x"), nil, wrapperspb.String("z"),
+		},
+		Timestamps: map[string]*timestamppb.Timestamp{
+			"now": timestamppb.New(now),
+		},
+	}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			v      any
+			expect any
+		}{
+			"wrapper": {
+				query:  query.New().Key("string_value"),
+				v:      msg,
+				expect: "aaa",
+			},
+			"int64 wrapper": {
+				query:  query.New().Key("int64Value"),
+				v:      msg,
+				expect: int64(10),
+			},
+			"nil wrapper": {
+				query:  query.New().Key("bool_value"),
+				v:      msg,
+				expect: nil,
+			},
+			"struct": {
+				query:  query.New().Key("struct").Key("a").Key("b").Index(1),
+				v:      msg,
+				expect: 1.5,
+			},
+			"struct as map": {
+				query: query.New().Key("struct").Key("a"),
+				v:     msg,
+				expect: map[string]any{
+					"b": []any{"x", 1.5, true, nil},
+				},
+			},
+			"null in struct": {
+				query:  query.New().Key("struct").Key("a").Key("b").Index(3),
+				v:      msg,
+				expect: nil,
+			},
+			"list value": {
+				query:  query.New().Key("list_value").Index(0),
+				v:      msg,
+				expect: "first",
+			},
+			"value": {
+				query:  query.New().Key("value"),
+				v:      msg,
+				expect: 2.0,
+			},
+			"timestamp": {
+				query:  query.New().Key("timestamp"),
+				v:      msg,
+				expect: now,
+			},
+			"duration": {
+				query:  query.New().Key("duration"),
+				v:      msg,
+				expect: 3 * time.Second,
+			},
+			"repeated wrappers": {
+				query:  query.New().Key("string_values"),
+				v:      msg,
+				expect: []string{"x", "", "z"},
+			},
+			"element of repeated wrappers": {
+				query:  query.New().Key("string_values").Index(2),
+				v:      msg,
+				expect: "z",
+			},
+			"map of timestamps": {
+				query:  query.New().Key("timestamps"),
+				v:      msg,
+				expect: map[string]time.Time{"now": now},
+			},
+			"value of map of timestamps": {
+				query:  query.New().Key("timestamps").Key("now"),
+				v:      msg,
+				expect: now,
+			},
+			"root struct": {
+				query:  query.New().Key("a").Key("b").Index(0),
+				v:      s,
+				expect: "x",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				q := query.New(
+					query.CustomExtractFunc(ExtractFunc(UnwrapWellKnownTypes())),
+				).Append(test.query.Extractors()...)
+				got, err := q.Extract(context.Background(), test.v)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if !reflect.DeepEqual(got, test.expect) {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			v      any
+			expect string
+		}{
+			"key not found in struct": {
+				query:  query.New().Key("struct").Key("b"),
+				v:      msg,
+				expect: `".struct.b" not found`,
+			},
+			"internal field of struct": {
+				query:  query.New().Key("struct").Key("fields"),
+				v:      msg,
+				expect: `".struct.fields" not found`,
+			},
+			"wrapper value field": {
+				query:  query.New().Key("string_value").Key("value"),
+				v:      msg,
+				expect: `".string_value.value" not found`,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				q := query.New(
+					query.CustomExtractFunc(ExtractFunc(UnwrapWellKnownTypes())),
+				).Append(test.query.Extractors()...)
+				_, err := q.Extract(context.Background(), test.v)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); got != test.expect {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+}
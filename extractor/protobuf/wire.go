@@ -0,0 +1,452 @@
+package protobuf
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+// WireMessage represents a message in the wire format which decodes only
+// the fields along the query path instead of unmarshaling the whole message.
+// It implements the query.KeyExtractor interface, so it can be the target of
+// a query without any option:
+//
+//	q.Extract(ctx, protobuf.NewWireMessage(b, (*pb.Message)(nil).ProtoReflect().Descriptor()))
+//
+// A field can be selected by the proto field name, the JSON name, or the
+// field number prefixed with "#", like ExtractFunc. The field values are
+// decoded as follows, following the merge semantics of the wire format:
+//
+//   - a scalar field is decoded as the Go type of protoreflect.Value (e.g.
+//     int32, string, protoreflect.EnumNumber); if the field occurs several
+//     times, the last one wins
+//   - a message field is decoded as a *WireMessage of the concatenation of
+//     all occurrences, which is equivalent to merging them
+//   - a repeated field is decoded as a slice, accepting both the packed and
+//     the unpacked encoding
+//   - a map field is decoded as a map; if a key occurs several times, the
+//     last one wins
+//
+// An absent field with presence (e.g. a message field or a field of a oneof)
+// is not found, and an absent field without presence is decoded as its
+// default value. A field of a oneof is also not found if another field of the
+// oneof occurs after it, since the last one wins. Bytes fields and messages
+// alias the original bytes.
+type WireMessage struct {
+	b  []byte
+	md protoreflect.MessageDescriptor
+}
+
+// NewWireMessage returns a new WireMessage of b which is a message of md in
+// the wire format.
+func NewWireMessage(b []byte, md protoreflect.MessageDescriptor) *WireMessage {
+	return &WireMessage{b: b, md: md}
+}
+
+// Bytes returns the message in the wire format.
+func (m *WireMessage) Bytes() []byte {
+	return m.b
+}
+
+// Descriptor returns the descriptor of the message.
+func (m *WireMessage) Descriptor() protoreflect.MessageDescriptor {
+	return m.md
+}
+
+// Unmarshal unmarshals the whole message into dst.
+func (m *WireMessage) Unmarshal(dst proto.Message) error {
+	return proto.Unmarshal(m.b, dst)
+}
+
+// ExtractByKey implements the query.KeyExtractor interface.
+func (m *WireMessage) ExtractByKey(ctx context.Context, key string) (any, error) {
+	fd := m.field(key, query.IsCaseInsensitive(ctx))
+	if fd == nil {
+		return nil, query.ErrNotFound
+	}
+	var (
+		v   any
+		err error
+	)
+	switch {
+	case fd.IsMap():
+		v, err = m.decodeMap(fd)
+	case fd.IsList():
+		v, err = m.decodeList(fd)
+	default:
+		v, err = m.decodeSingular(fd)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", fd.FullName(), err)
+	}
+	return v, nil
+}
+
+func (m *WireMessage) field(key string, ci bool) protoreflect.FieldDescriptor {
+	fds := m.md.Fields()
+	if s, ok := strings.CutPrefix(key, "#"); ok {
+		if num, err := strconv.Atoi(s); err == nil {
+			return fds.ByNumber(protoreflect.FieldNumber(num))
+		}
+	}
+	if fd := fds.ByName(protoreflect.Name(key)); fd != nil {
+		return fd
+	}
+	if fd := fds.ByJSONName(key); fd != nil {
+		return fd
+	}
+	if ci {
+		for i := range fds.Len() {
+			fd := fds.Get(i)
+			if strings.EqualFold(string(fd.Name()), key) || strings.EqualFold(fd.JSONName(), key) {
+				return fd
+			}
+		}
+	}
+	return nil
+}
+
+// rangeField calls f with the wire type and the encoded value of each
+// occurrence of the field num, skipping the other fields.
+func (m *WireMessage) rangeField(num protowire.Number, f func(typ protowire.Type, b []byte) error) error {
+	b := m.b
+	for len(b) > 0 {
+		n, typ, l := protowire.ConsumeTag(b)
+		if l < 0 {
+			return protowire.ParseError(l)
+		}
+		b = b[l:]
+		l = protowire.ConsumeFieldValue(n, typ, b)
+		if l < 0 {
+			return protowire.ParseError(l)
+		}
+		if n == num {
+			if err := f(typ, b[:l]); err != nil {
+				return err
+			}
+		}
+		b = b[l:]
+	}
+	return nil
+}
+
+func (m *WireMessage) decodeSingular(fd protoreflect.FieldDescriptor) (any, error) {
+	if od := fd.ContainingOneof(); od != nil && !od.IsSynthetic() {
+		// Setting a member of a oneof clears the others, so only the
+		// occurrences after the last one of the other members count.
+		tail, ok, err := m.oneofTail(fd, od)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			return nil, query.ErrNotFound
+		}
+		m = &WireMessage{b: tail, md: m.md}
+	}
+	if fd.Message() != nil {
+		var (
+			b     []byte
+			found bool
+		)
+		err := m.rangeField(fd.Number(), func(typ protowire.Type, v []byte) error {
+			x, ok, err := decodeMessage(fd, typ, v)
+			if err != nil || !ok {
+				return err
+			}
+			if found {
+				// Concatenating the messages merges them; copy to avoid
+				// overwriting the original bytes.
+				b = append(b[:len(b):len(b)], x...)
+			} else {
+				b = x
+			}
+			found = true
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, query.ErrNotFound
+		}
+		return NewWireMessage(b, fd.Message()), nil
+	}
+	var (
+		last  protoreflect.Value
+		found bool
+	)
+	err := m.rangeField(fd.Number(), func(typ protowire.Type, v []byte) error {
+		x, _, ok, err := decodeScalar(fd, typ, v)
+		if err != nil || !ok {
+			return err
+		}
+		last, found = x, true
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		if fd.HasPresence() {
+			return nil, query.ErrNotFound
+		}
+		return fd.Default().Interface(), nil
+	}
+	return last.Interface(), nil
+}
+
+// oneofTail returns the bytes following the last occurrence of the members of
+// od other than fd, and reports whether fd is the member which occurs last.
+func (m *WireMessage) oneofTail(fd protoreflect.FieldDescriptor, od protoreflect.OneofDescriptor) ([]byte, bool, error) {
+	var last protowire.Number
+	b, tail := m.b, m.b
+	for len(b) > 0 {
+		n, typ, l := protowire.ConsumeTag(b)
+		if l < 0 {
+			return nil, false, protowire.ParseError(l)
+		}
+		b = b[l:]
+		l = protowire.ConsumeFieldValue(n, typ, b)
+		if l < 0 {
+			return nil, false, protowire.ParseError(l)
+		}
+		// An occurrence with an unexpected wire type is ignored like an
+		// unknown field.
+		if member := od.Fields().ByNumber(n); member != nil && typ == wireType(member.Kind()) {
+			if n != fd.Number() {
+				tail = b[l:]
+			}
+			last = n
+		}
+		b = b[l:]
+	}
+	return tail, last == fd.Number(), nil
+}
+
+func (m *WireMessage) decodeList(fd protoreflect.FieldDescriptor) (any, error) {
+	s := reflect.MakeSlice(reflect.SliceOf(goType(fd)), 0, 0)
+	err := m.rangeField(fd.Number(), func(typ protowire.Type, v []byte) error {
+		if fd.Message() != nil {
+			x, ok, err := decodeMessage(fd, typ, v)
+			if ok {
+				s = reflect.Append(s, reflect.ValueOf(NewWireMessage(x, fd.Message())))
+			}
+			return err
+		}
+		if typ == protowire.BytesType && isPackable(fd.Kind()) {
+			b, l := protowire.ConsumeBytes(v)
+			if l < 0 {
+				return protowire.ParseError(l)
+			}
+			for len(b) > 0 {
+				x, l, _, err := decodeScalar(fd, wireType(fd.Kind()), b)
+				if err != nil {
+					return err
+				}
+				s = reflect.Append(s, reflect.ValueOf(x.Interface()))
+				b = b[l:]
+			}
+			return nil
+		}
+		x, _, ok, err := decodeScalar(fd, typ, v)
+		if ok {
+			s = reflect.Append(s, reflect.ValueOf(x.Interface()))
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.Interface(), nil
+}
+
+func (m *WireMessage) decodeMap(fd protoreflect.FieldDescriptor) (any, error) {
+	kfd, vfd := fd.MapKey(), fd.MapValue()
+	res := reflect.MakeMap(reflect.MapOf(goType(kfd), goType(vfd)))
+	err := m.rangeField(fd.Number(), func(typ protowire.Type, v []byte) error {
+		b, ok, err := decodeMessage(fd, typ, v)
+		if err != nil || !ok {
+			return err
+		}
+		entry := NewWireMessage(b, fd.Message())
+		k, err := entry.decodeEntryField(kfd)
+		if err != nil {
+			return err
+		}
+		val, err := entry.decodeEntryField(vfd)
+		if err != nil {
+			return err
+		}
+		res.SetMapIndex(reflect.ValueOf(k), reflect.ValueOf(val))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res.Interface(), nil
+}
+
+// decodeEntryField decodes the key or value field of a map entry. An absent
+// field is the default value, or an empty message.
+func (m *WireMessage) decodeEntryField(fd protoreflect.FieldDescriptor) (any, error) {
+	v, err := m.decodeSingular(fd)
+	if errors.Is(err, query.ErrNotFound) {
+		if fd.Message() != nil {
+			return NewWireMessage(nil, fd.Message()), nil
+		}
+		return fd.Default().Interface(), nil
+	}
+	return v, err
+}
+
+// decodeMessage returns the encoded message of a message or group field. It
+// reports false if the wire type does not match, in which case the field is
+// ignored like an unknown field.
+func decodeMessage(fd protoreflect.FieldDescriptor, typ protowire.Type, b []byte) ([]byte, bool, error) {
+	var (
+		x []byte
+		l int
+	)
+	switch {
+	case fd.Kind() == protoreflect.GroupKind && typ == protowire.StartGroupType:
+		x, l = protowire.ConsumeGroup(fd.Number(), b)
+	case fd.Kind() != protoreflect.GroupKind && typ == protowire.BytesType:
+		x, l = protowire.ConsumeBytes(b)
+	default:
+		return nil, false, nil
+	}
+	if l < 0 {
+		return nil, false, protowire.ParseError(l)
+	}
+	return x, true, nil
+}
+
+// decodeScalar decodes a value of a scalar field and returns it with its
+// length. It reports false if the wire type does not match, in which case the
+// field is ignored like an unknown field.
+func decodeScalar(fd protoreflect.FieldDescriptor, typ protowire.Type, b []byte) (protoreflect.Value, int, bool, error) {
+	if typ != wireType(fd.Kind()) {
+		return protoreflect.Value{}, 0, false, nil
+	}
+	var (
+		v protoreflect.Value
+		l int
+	)
+	switch typ {
+	case protowire.VarintType:
+		var x uint64
+		x, l = protowire.ConsumeVarint(b)
+		switch fd.Kind() {
+		case protoreflect.BoolKind:
+			v = protoreflect.ValueOfBool(protowire.DecodeBool(x))
+		case protoreflect.EnumKind:
+			v = protoreflect.ValueOfEnum(protoreflect.EnumNumber(int32(x)))
+		case protoreflect.Int32Kind:
+			v = protoreflect.ValueOfInt32(int32(x))
+		case protoreflect.Sint32Kind:
+			v = protoreflect.ValueOfInt32(int32(protowire.DecodeZigZag(x & math.MaxUint32)))
+		case protoreflect.Uint32Kind:
+			v = protoreflect.ValueOfUint32(uint32(x))
+		case protoreflect.Int64Kind:
+			v = protoreflect.ValueOfInt64(int64(x))
+		case protoreflect.Sint64Kind:
+			v = protoreflect.ValueOfInt64(protowire.DecodeZigZag(x))
+		case protoreflect.Uint64Kind:
+			v = protoreflect.ValueOfUint64(x)
+		}
+	case protowire.Fixed32Type:
+		var x uint32
+		x, l = protowire.ConsumeFixed32(b)
+		switch fd.Kind() {
+		case protoreflect.Sfixed32Kind:
+			v = protoreflect.ValueOfInt32(int32(x))
+		case protoreflect.Fixed32Kind:
+			v = protoreflect.ValueOfUint32(x)
+		case protoreflect.FloatKind:
+			v = protoreflect.ValueOfFloat32(math.Float32frombits(x))
+		}
+	case protowire.Fixed64Type:
+		var x uint64
+		x, l = protowire.ConsumeFixed64(b)
+		switch fd.Kind() {
+		case protoreflect.Sfixed64Kind:
+			v = protoreflect.ValueOfInt64(int64(x))
+		case protoreflect.Fixed64Kind:
+			v = protoreflect.ValueOfUint64(x)
+		case protoreflect.DoubleKind:
+			v = protoreflect.ValueOfFloat64(math.Float64frombits(x))
+		}
+	case protowire.BytesType:
+		var x []byte
+		x, l = protowire.ConsumeBytes(b)
+		if fd.Kind() == protoreflect.StringKind {
+			v = protoreflect.ValueOfString(string(x))
+		} else {
+			v = protoreflect.ValueOfBytes(x)
+		}
+	}
+	if l < 0 {
+		return protoreflect.Value{}, 0, false, protowire.ParseError(l)
+	}
+	return v, l, true, nil
+}
+
+// wireType returns the wire type of a non-packed value of kind k.
+func wireType(k protoreflect.Kind) protowire.Type {
+	switch k {
+	case protoreflect.BoolKind, protoreflect.EnumKind,
+		protoreflect.Int32Kind, protoreflect.Sint32Kind, protoreflect.Uint32Kind,
+		protoreflect.Int64Kind, protoreflect.Sint64Kind, protoreflect.Uint64Kind:
+		return protowire.VarintType
+	case protoreflect.Sfixed32Kind, protoreflect.Fixed32Kind, protoreflect.FloatKind:
+		return protowire.Fixed32Type
+	case protoreflect.Sfixed64Kind, protoreflect.Fixed64Kind, protoreflect.DoubleKind:
+		return protowire.Fixed64Type
+	case protoreflect.GroupKind:
+		return protowire.StartGroupType
+	}
+	return protowire.BytesType
+}
+
+func isPackable(k protoreflect.Kind) bool {
+	return wireType(k) != protowire.BytesType && k != protoreflect.GroupKind
+}
+
+var (
+	wireMessageType = reflect.TypeFor[*WireMessage]()
+	goTypes         = map[protoreflect.Kind]reflect.Type{
+		protoreflect.BoolKind:     reflect.TypeFor[bool](),
+		protoreflect.EnumKind:     reflect.TypeFor[protoreflect.EnumNumber](),
+		protoreflect.Int32Kind:    reflect.TypeFor[int32](),
+		protoreflect.Sint32Kind:   reflect.TypeFor[int32](),
+		protoreflect.Sfixed32Kind: reflect.TypeFor[int32](),
+		protoreflect.Uint32Kind:   reflect.TypeFor[uint32](),
+		protoreflect.Fixed32Kind:  reflect.TypeFor[uint32](),
+		protoreflect.Int64Kind:    reflect.TypeFor[int64](),
+		protoreflect.Sint64Kind:   reflect.TypeFor[int64](),
+		protoreflect.Sfixed64Kind: reflect.TypeFor[int64](),
+		protoreflect.Uint64Kind:   reflect.TypeFor[uint64](),
+		protoreflect.Fixed64Kind:  reflect.TypeFor[uint64](),
+		protoreflect.FloatKind:    reflect.TypeFor[float32](),
+		protoreflect.DoubleKind:   reflect.TypeFor[float64](),
+		protoreflect.StringKind:   reflect.TypeFor[string](),
+		protoreflect.BytesKind:    reflect.TypeFor[[]byte](),
+		protoreflect.MessageKind:  wireMessageType,
+		protoreflect.GroupKind:    wireMessageType,
+	}
+)
+
+// goType returns the Go type which a value of fd is decoded as.
+func goType(fd protoreflect.FieldDescriptor) reflect.Type {
+	return goTypes[fd.Kind()]
+}
@@ -0,0 +1,280 @@
+package protobuf
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+
+	testpb "github.com/zoncoen/query-go/extractor/protobuf/testdata/gen/testpb"
+	"github.com/zoncoen/query-go/v2"
+)
+
+func TestWireMessage(t *testing.T) {
+	md := (&testpb.Message{}).ProtoReflect().Descriptor()
+	marshal := func(t *testing.T, msgs ...*testpb.Message) []byte {
+		t.Helper()
+		var b []byte
+		for _, msg := range msgs {
+			x, err := proto.Marshal(msg)
+			if err != nil {
+				t.Fatal(err)
+			}
+			b = append(b, x...)
+		}
+		return b
+	}
+	b := marshal(t, &testpb.Message{
+		Name:  "aaa",
+		Count: 1,
+		Nested: &testpb.Message_Nested{
+			Value: "bbb",
+		},
+		NestedList: []*testpb.Message_Nested{
+			{Value: "c0"},
+			{Value: "c1"},
+		},
+		NestedMap: map[string]*testpb.Message_Nested{
+			"k": {Value: "ddd"},
+		},
+		Status:    testpb.Status_STATUS_ACTIVE,
+		Statuses:  []testpb.Status{testpb.Status_STATUS_ACTIVE, testpb.Status_STATUS_INACTIVE},
+		StatusMap: map[string]testpb.Status{"s": testpb.Status_STATUS_INACTIVE},
+		Payload:   &testpb.Message_Text{Text: "eee"},
+		Numbers:   []int32{1, -2, 3},
+		Delta:     -5,
+		Ratio:     0.5,
+		Checksum:  0xffffffff,
+		Data:      []byte("fff"),
+		Labels:    map[int32]string{-1: "minus one"},
+	}, &testpb.Message{
+		Count: 2,
+		Nested: &testpb.Message_Nested{
+			Child: &testpb.Message_Nested{Value: "ggg"},
+		},
+		NestedList: []*testpb.Message_Nested{
+			{Value: "c2"},
+		},
+		NestedMap: map[string]*testpb.Message_Nested{
+			"k": {Value: "hhh"},
+		},
+	})
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			b      []byte
+			expect any
+		}{
+			"string": {
+				query:  query.New().Key("name"),
+				expect: "aaa",
+			},
+			"last one wins": {
+				query:  query.New().Key("count"),
+				expect: int32(2),
+			},
+			"merged message": {
+				query:  query.New().Key("nested").Key("value"),
+				expect: "bbb",
+			},
+			"merged message (nested)": {
+				query:  query.New().Key("nested").Key("child").Key("value"),
+				expect: "ggg",
+			},
+			"repeated message": {
+				query:  query.New().Key("nested_list").Index(-1).Key("value"),
+				expect: "c2",
+			},
+			"map of messages": {
+				query:  query.New().Key("nested_map").Key("k").Key("value"),
+				expect: "hhh",
+			},
+			"enum": {
+				query:  query.New().Key("status"),
+				expect: protoreflect.EnumNumber(1),
+			},
+			"packed enums": {
+				query:  query.New().Key("statuses"),
+				expect: []protoreflect.EnumNumber{1, 2},
+			},
+			"map of enums": {
+				query:  query.New().Key("statusMap"),
+				expect: map[string]protoreflect.EnumNumber{"s": 2},
+			},
+			"oneof": {
+				query:  query.New().Key("text"),
+				expect: "eee",
+			},
+			"packed int32": {
+				query:  query.New().Key("numbers"),
+				expect: []int32{1, -2, 3},
+			},
+			"sint64": {
+				query:  query.New().Key("delta"),
+				expect: int64(-5),
+			},
+			"double": {
+				query:  query.New().Key("ratio"),
+				expect: 0.5,
+			},
+			"fixed32": {
+				query:  query.New().Key("checksum"),
+				expect: uint32(0xffffffff),
+			},
+			"bytes": {
+				query:  query.New().Key("data"),
+				expect: []byte("fff"),
+			},
+			"map with integer keys": {
+				query:  query.New().Key("labels").Index(-1),
+				expect: "minus one",
+			},
+			"field number": {
+				query:  query.New().Key("#1"),
+				expect: "aaa",
+			},
+			"case insensitive": {
+				query:  query.New(query.CaseInsensitive()).Key("NESTEDLIST").Index(0).Key("VALUE"),
+				expect: "c0",
+			},
+			"absent field without presence": {
+				query:  query.New().Key("nested").Key("child").Key("child").Key("value"),
+				b:      marshal(t, &testpb.Message{Nested: &testpb.Message_Nested{Child: &testpb.Message_Nested{Child: &testpb.Message_Nested{}}}}),
+				expect: "",
+			},
+			"absent repeated field": {
+				query:  query.New().Key("numbers"),
+				b:      []byte{},
+				expect: []int32{},
+			},
+			"unpacked repeated field": {
+				query: query.New().Key("numbers"),
+				b: func() []byte {
+					var b []byte
+					for _, n := range []uint64{7, 8} {
+						b = protowire.AppendTag(b, 13, protowire.VarintType)
+						b = protowire.AppendVarint(b, n)
+					}
+					return b
+				}(),
+				expect: []int32{7, 8},
+			},
+			"last oneof member wins": {
+				query:  query.New().Key("nested_payload").Key("value"),
+				b:      marshal(t, &testpb.Message{Payload: &testpb.Message_Text{Text: "x"}}, &testpb.Message{Payload: &testpb.Message_NestedPayload{NestedPayload: &testpb.Message_Nested{Value: "y"}}}),
+				expect: "y",
+			},
+			"oneof member merged after the other member": {
+				query: query.New().Key("nested_payload"),
+				b: marshal(t,
+					&testpb.Message{Payload: &testpb.Message_NestedPayload{NestedPayload: &testpb.Message_Nested{Value: "discarded"}}},
+					&testpb.Message{Payload: &testpb.Message_Text{Text: "x"}},
+					&testpb.Message{Payload: &testpb.Message_NestedPayload{NestedPayload: &testpb.Message_Nested{Child: &testpb.Message_Nested{}}}},
+				),
+				expect: NewWireMessage([]byte{0x12, 0x00}, md.Fields().ByName("nested_payload").Message()),
+			},
+			"field with unexpected wire type": {
+				query: query.New().Key("count"),
+				b: func() []byte {
+					b := protowire.AppendTag(nil, 2, protowire.VarintType)
+					b = protowire.AppendVarint(b, 3)
+					b = protowire.AppendTag(b, 2, protowire.BytesType)
+					return protowire.AppendBytes(b, []byte("x"))
+				}(),
+				expect: int32(3),
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				in := b
+				if test.b != nil {
+					in = test.b
+				}
+				got, err := test.query.Extract(context.Background(), NewWireMessage(in, md))
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if !reflect.DeepEqual(got, test.expect) {
+					t.Errorf("expect %#v but got %#v", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("message", func(t *testing.T) {
+		got, err := query.New().Key("nested").Extract(context.Background(), NewWireMessage(b, md))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		wm, ok := got.(*WireMessage)
+		if !ok {
+			t.Fatalf("expect *WireMessage but got %T", got)
+		}
+		if got, expect := wm.Descriptor().FullName(), md.Fields().ByName("nested").Message().FullName(); got != expect {
+			t.Errorf("expect %s but got %s", expect, got)
+		}
+		var nested testpb.Message_Nested
+		if err := wm.Unmarshal(&nested); err != nil {
+			t.Fatal(err)
+		}
+		expect := &testpb.Message_Nested{
+			Value: "bbb",
+			Child: &testpb.Message_Nested{Value: "ggg"},
+		}
+		if !proto.Equal(&nested, expect) {
+			t.Errorf("expect %v but got %v", expect, &nested)
+		}
+		if !reflect.DeepEqual(b, NewWireMessage(b, md).Bytes()) {
+			t.Error("bytes differ")
+		}
+	})
+	t.Run("not found", func(t *testing.T) {
+		tests := map[string]*query.Query{
+			"unknown field":          query.New().Key("foo"),
+			"absent message":         query.New().Key("nested").Key("child").Key("child"),
+			"absent optional scalar": query.New().Key("optional_count"),
+			"absent oneof field":     query.New().Key("nested_payload"),
+			"absent map key":         query.New().Key("nested_map").Key("x"),
+		}
+		// Encoding another member of the oneof clears the previous one.
+		oneof := marshal(t, &testpb.Message{Payload: &testpb.Message_Text{Text: "x"}}, &testpb.Message{Payload: &testpb.Message_NestedPayload{NestedPayload: &testpb.Message_Nested{Value: "y"}}})
+		t.Run("oneof member overwritten by another member", func(t *testing.T) {
+			_, err := query.New().Key("text").Extract(context.Background(), NewWireMessage(oneof, md))
+			if !errors.Is(err, query.ErrNotFound) {
+				t.Fatalf("expect not found error but got %v", err)
+			}
+			var msg testpb.Message
+			if err := proto.Unmarshal(oneof, &msg); err != nil {
+				t.Fatal(err)
+			}
+			if _, ok := msg.Payload.(*testpb.Message_NestedPayload); !ok {
+				t.Fatalf("expect nested_payload to be set but got %T", msg.Payload)
+			}
+		})
+		for name, q := range tests {
+			q := q
+			t.Run(name, func(t *testing.T) {
+				_, err := q.Extract(context.Background(), NewWireMessage(b, md))
+				if !errors.Is(err, query.ErrNotFound) {
+					t.Fatalf("expect not found error but got %v", err)
+				}
+			})
+		}
+	})
+	t.Run("malformed", func(t *testing.T) {
+		_, err := query.New().Key("name").Extract(context.Background(), NewWireMessage([]byte{0x0a, 0x05, 'a'}, md))
+		if err == nil {
+			t.Fatal("no error")
+		}
+		if errors.Is(err, query.ErrNotFound) {
+			t.Fatalf("a malformed message must not be reported as not found: %s", err)
+		}
+		if got, expect := err.Error(), ".name: failed to decode com.github.zoncoen.querygo.extractor.protobuf.Message.name: unexpected EOF"; got != expect {
+			t.Errorf("expect %q but got %q", expect, got)
+		}
+	})
+}
@@ -0,0 +1,385 @@
+package yaml
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+// indentWidth is the indentation width of a new block collection whose
+// column can't be taken from the replaced node.
+const indentWidth = 2
+
+// Replace replaces the value selected by q in the first document of file with
+// newValue, keeping the other nodes, the comments and the indentation, so
+// that file.String() re-serializes the edited source. newValue is converted
+// to a node by yaml.ValueToNode unless it is an ast.Node. The line comment of
+// the replaced value is kept if newValue is a scalar or a flow collection.
+//
+// The keys of q match the explicit keys exactly; merge keys and aliases are
+// not followed since editing the merged or aliased value would change other
+// locations as well.
+func Replace(file *ast.File, q *query.Query, newValue any) error {
+	t, err := locate(file, q)
+	if err != nil {
+		return err
+	}
+	node, err := toNode(newValue)
+	if err != nil {
+		return err
+	}
+	switch parent := t.parent.(type) {
+	case nil:
+		align(node, startColumn(t.doc.Body))
+		t.doc.Body = node
+	case *ast.MappingNode:
+		mv := findMappingValue(parent, t.key)
+		if mv == nil {
+			return t.notFound()
+		}
+		replaceValue(mv, node)
+	case *ast.SequenceNode:
+		i, ok := normalizeIndex(t.index, len(parent.Values))
+		if !ok {
+			return t.notFound()
+		}
+		old := parent.Values[i]
+		align(node, startColumn(old))
+		keepComment(node, old)
+		parent.Values[i] = node
+	}
+	return nil
+}
+
+// Delete deletes the mapping entry or the sequence entry selected by q from
+// the first document of file. A mapping or a sequence which becomes empty is
+// re-serialized in flow style ("{}" or "[]").
+func Delete(file *ast.File, q *query.Query) error {
+	t, err := locate(file, q)
+	if err != nil {
+		return err
+	}
+	switch parent := t.parent.(type) {
+	case nil:
+		return fmt.Errorf("%s: can not delete the root", q.String())
+	case *ast.MappingNode:
+		for i, mv := range parent.Values {
+			if !mv.Key.IsMergeKey() && mapKeyString(mv.Key) == t.key {
+				parent.Values = append(parent.Values[:i], parent.Values[i+1:]...)
+				if len(parent.Values) == 0 {
+					parent.IsFlowStyle = true
+				}
+				return nil
+			}
+		}
+		return t.notFound()
+	case *ast.SequenceNode:
+		i, ok := normalizeIndex(t.index, len(parent.Values))
+		if !ok {
+			return t.notFound()
+		}
+		if i == 0 && !parent.IsFlowStyle {
+			// The head comment of the first entry belongs to the sequence.
+			_ = parent.SetComment(nil)
+		}
+		if len(parent.ValueHeadComments) == len(parent.Values) {
+			parent.ValueHeadComments = append(parent.ValueHeadComments[:i], parent.ValueHeadComments[i+1:]...)
+		}
+		parent.Values = append(parent.Values[:i], parent.Values[i+1:]...)
+		if len(parent.Values) == 0 {
+			parent.IsFlowStyle = true
+		}
+	}
+	return nil
+}
+
+// Insert inserts newValue to the first document of file at the location
+// selected by q. If the last extractor of q is a key, the entry of the key is
+// added to the end of the mapping, and it is an error if the key already
+// exists. If it is an index, newValue is inserted before the sequence entry at
+// the index; the length of the sequence appends newValue. newValue is
+// converted to a node like Replace.
+func Insert(file *ast.File, q *query.Query, newValue any) error {
+	t, err := locate(file, q)
+	if err != nil {
+		return err
+	}
+	node, err := toNode(newValue)
+	if err != nil {
+		return err
+	}
+	switch parent := t.parent.(type) {
+	case nil:
+		return fmt.Errorf("%s: can not insert the root", q.String())
+	case *ast.MappingNode:
+		if findMappingValue(parent, t.key) != nil {
+			return fmt.Errorf("%s: key %q already exists", q.String(), t.key)
+		}
+		entry, err := newMappingValue(t.key)
+		if err != nil {
+			return err
+		}
+		if len(parent.Values) > 0 {
+			align(entry, startColumn(parent.Values[0]))
+		}
+		replaceValue(entry, node)
+		if parent.IsFlowStyle {
+			setFlowStyle(node)
+		}
+		parent.Values = append(parent.Values, entry)
+	case *ast.SequenceNode:
+		i := t.index
+		if i < 0 {
+			i += len(parent.Values)
+		}
+		if i < 0 || len(parent.Values) < i {
+			return t.notFound()
+		}
+		if len(parent.Values) > 0 {
+			align(node, startColumn(parent.Values[0]))
+		}
+		if parent.IsFlowStyle {
+			setFlowStyle(node)
+		}
+		if len(parent.ValueHeadComments) != len(parent.Values) {
+			parent.ValueHeadComments = make([]*ast.CommentGroupNode, len(parent.Values))
+		}
+		parent.ValueHeadComments = append(parent.ValueHeadComments[:i], append([]*ast.CommentGroupNode{nil}, parent.ValueHeadComments[i:]...)...)
+		if c := parent.GetComment(); i == 0 && c != nil && !parent.IsFlowStyle && len(parent.Values) > 0 {
+			// Keep the head comment of the first entry on the entry.
+			parent.ValueHeadComments[1] = c
+			_ = parent.SetComment(nil)
+		}
+		parent.Values = append(parent.Values[:i], append([]ast.Node{node}, parent.Values[i:]...)...)
+	}
+	return nil
+}
+
+// editTarget represents the location selected by a query to edit.
+type editTarget struct {
+	query *query.Query
+	doc   *ast.DocumentNode
+	// parent is the mapping or the sequence which has the location, or nil
+	// if the location is the root.
+	parent ast.Node
+	key    string
+	index  int
+}
+
+func (t *editTarget) notFound() error {
+	return &query.NotFoundError{
+		Query:    t.query.String(),
+		FailedAt: t.query.String(),
+		Err:      query.ErrNotFound,
+	}
+}
+
+// locate returns the location selected by q in the first document of file.
+// The last extractor of q is not applied, so that the caller can edit the
+// parent.
+func locate(file *ast.File, q *query.Query) (*editTarget, error) {
+	if file == nil || len(file.Docs) == 0 {
+		return nil, errors.New("no YAML document")
+	}
+	t := &editTarget{
+		query: q,
+		doc:   file.Docs[0],
+	}
+	es := q.Extractors()
+	node := t.doc.Body
+	for i, e := range es {
+		parent, err := editableNode(node)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", failedAt(q, es, i), err)
+		}
+		last := i == len(es)-1
+		switch e := e.(type) {
+		case *query.Key:
+			m, ok := parent.(*ast.MappingNode)
+			if !ok {
+				return nil, notFound(q, es, i)
+			}
+			if last {
+				t.parent, t.key = m, e.Key()
+				return t, nil
+			}
+			mv := findMappingValue(m, e.Key())
+			if mv == nil {
+				return nil, notFound(q, es, i)
+			}
+			node = mv.Value
+		case *query.Index:
+			seq, ok := parent.(*ast.SequenceNode)
+			if !ok {
+				return nil, notFound(q, es, i)
+			}
+			if last {
+				t.parent, t.index = seq, e.Index()
+				return t, nil
+			}
+			j, ok := normalizeIndex(e.Index(), len(seq.Values))
+			if !ok {
+				return nil, notFound(q, es, i)
+			}
+			node = seq.Values[j]
+		default:
+			return nil, fmt.Errorf("%s: unsupported extractor %T", failedAt(q, es, i), e)
+		}
+	}
+	return t, nil
+}
+
+// editableNode looks through the anchor and tag nodes. It returns an error if
+// node is an alias node.
+func editableNode(node ast.Node) (ast.Node, error) {
+	for {
+		switch x := node.(type) {
+		case *ast.AnchorNode:
+			node = x.Value
+		case *ast.TagNode:
+			node = x.Value
+		case *ast.AliasNode:
+			return nil, fmt.Errorf("can not edit through alias *%s", aliasName(x))
+		default:
+			return node, nil
+		}
+	}
+}
+
+// failedAt returns the string representation of q up to and including the
+// i-th extractor of es.
+func failedAt(q *query.Query, es []query.Extractor, i int) string {
+	var suffix strings.Builder
+	for _, e := range es[i+1:] {
+		suffix.WriteString(e.String())
+	}
+	return strings.TrimSuffix(q.String(), suffix.String())
+}
+
+func notFound(q *query.Query, es []query.Extractor, i int) error {
+	return &query.NotFoundError{
+		Query:    q.String(),
+		FailedAt: failedAt(q, es, i),
+		Err:      query.ErrNotFound,
+	}
+}
+
+func findMappingValue(m *ast.MappingNode, key string) *ast.MappingValueNode {
+	for _, mv := range m.Values {
+		if !mv.Key.IsMergeKey() && mapKeyString(mv.Key) == key {
+			return mv
+		}
+	}
+	return nil
+}
+
+func normalizeIndex(i, length int) (int, bool) {
+	if i < 0 {
+		i += length
+	}
+	return i, 0 <= i && i < length
+}
+
+func toNode(v any) (ast.Node, error) {
+	if n, ok := v.(ast.Node); ok {
+		return n, nil
+	}
+	n, err := yaml.ValueToNode(v)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %T to YAML node: %w", v, err)
+	}
+	return n, nil
+}
+
+// newMappingValue returns a new mapping entry of key which has null.
+func newMappingValue(key string) (*ast.MappingValueNode, error) {
+	n, err := yaml.ValueToNode(yaml.MapSlice{{Key: key, Value: nil}})
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert key %q to YAML node: %w", key, err)
+	}
+	m, ok := n.(*ast.MappingNode)
+	if !ok || len(m.Values) != 1 {
+		return nil, fmt.Errorf("failed to convert key %q to YAML node", key)
+	}
+	return m.Values[0], nil
+}
+
+// replaceValue replaces the value of mv with node. A block collection is
+// indented like the replaced one, or by indentWidth if the replaced value is
+// not a block collection.
+func replaceValue(mv *ast.MappingValueNode, node ast.Node) {
+	old := mv.Value
+	switch {
+	case isBlock(node) && isBlock(old):
+		align(node, startColumn(old))
+	case isBlock(node):
+		align(node, mv.Key.GetToken().Position.Column+indentWidth)
+	default:
+		align(node, startColumn(old))
+		keepComment(node, old)
+	}
+	mv.Value = node
+}
+
+// keepComment moves the line comment of old to node unless node is a block
+// collection or has its own comment.
+func keepComment(node, old ast.Node) {
+	if isBlock(node) || node.GetComment() != nil {
+		return
+	}
+	if c := old.GetComment(); c != nil {
+		_ = node.SetComment(c)
+	}
+}
+
+func isBlock(node ast.Node) bool {
+	switch x := node.(type) {
+	case *ast.MappingNode:
+		return !x.IsFlowStyle && len(x.Values) > 0
+	case *ast.MappingValueNode:
+		return true
+	case *ast.SequenceNode:
+		return !x.IsFlowStyle && len(x.Values) > 0
+	case *ast.AnchorNode:
+		return isBlock(x.Value)
+	case *ast.TagNode:
+		return isBlock(x.Value)
+	}
+	return false
+}
+
+func setFlowStyle(node ast.Node) {
+	switch x := node.(type) {
+	case *ast.MappingNode:
+		x.SetIsFlowStyle(true)
+	case *ast.SequenceNode:
+		x.SetIsFlowStyle(true)
+	}
+}
+
+// startColumn returns the column where node starts. The token of a block
+// mapping is not the first key, so the column of the first key is used.
+func startColumn(node ast.Node) int {
+	if node == nil {
+		return 1
+	}
+	switch x := node.(type) {
+	case *ast.MappingNode:
+		if len(x.Values) > 0 {
+			return startColumn(x.Values[0])
+		}
+	case *ast.MappingValueNode:
+		return x.Key.GetToken().Position.Column
+	}
+	return node.GetToken().Position.Column
+}
+
+// align moves node to start at column.
+func align(node ast.Node, column int) {
+	node.AddColumn(column - startColumn(node))
+}
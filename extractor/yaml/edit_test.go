@@ -0,0 +1,212 @@
+package yaml
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+const editTestYAML = `# service
+name: app # the name
+timeout: 30
+defaults: &defaults
+  retry: 1
+servers:
+  # primary
+  - host: a.example.com
+    port: 80
+  - host: b.example.com
+    port: 8080
+backup: *defaults
+tags: [a, b]
+`
+
+func parseEditTestYAML(t *testing.T) *ast.File {
+	t.Helper()
+	f, err := parser.ParseBytes([]byte(editTestYAML), parser.ParseComments)
+	if err != nil {
+		t.Fatalf("failed to parse: %s", err)
+	}
+	return f
+}
+
+func TestEdit(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			edit   func(*ast.File) error
+			expect string
+		}{
+			"replace scalar": {
+				edit: func(f *ast.File) error {
+					return Replace(f, query.New().Key("name"), "new")
+				},
+				expect: strings.Replace(editTestYAML, "name: app # the name", "name: new # the name", 1),
+			},
+			"replace scalar with mapping": {
+				edit: func(f *ast.File) error {
+					return Replace(f, query.New().Key("timeout"), map[string]int{"read": 10})
+				},
+				expect: strings.Replace(editTestYAML, "timeout: 30\n", "timeout:\n  read: 10\n", 1),
+			},
+			"replace in sequence": {
+				edit: func(f *ast.File) error {
+					return Replace(f, query.New().Key("servers").Index(-1).Key("port"), 443)
+				},
+				expect: strings.Replace(editTestYAML, "port: 8080", "port: 443", 1),
+			},
+			"replace sequence entry": {
+				edit: func(f *ast.File) error {
+					return Replace(f, query.New().Key("tags").Index(0), "x")
+				},
+				expect: strings.Replace(editTestYAML, "[a, b]", "[x, b]", 1),
+			},
+			"replace in anchor": {
+				edit: func(f *ast.File) error {
+					return Replace(f, query.New().Key("defaults").Key("retry"), 3)
+				},
+				expect: strings.Replace(editTestYAML, "retry: 1", "retry: 3", 1),
+			},
+			"delete mapping entry": {
+				edit: func(f *ast.File) error {
+					return Delete(f, query.New().Key("timeout"))
+				},
+				expect: strings.Replace(editTestYAML, "timeout: 30\n", "", 1),
+			},
+			"delete sequence entry": {
+				edit: func(f *ast.File) error {
+					return Delete(f, query.New().Key("servers").Index(0))
+				},
+				expect: strings.Replace(editTestYAML, "  # primary\n  - host: a.example.com\n    port: 80\n", "", 1),
+			},
+			"delete last entry": {
+				edit: func(f *ast.File) error {
+					return Delete(f, query.New().Key("defaults").Key("retry"))
+				},
+				expect: strings.Replace(editTestYAML, "defaults: &defaults\n  retry: 1\n", "defaults: &defaults {}\n", 1),
+			},
+			"insert key": {
+				edit: func(f *ast.File) error {
+					return Insert(f, query.New().Key("defaults").Key("verbose"), true)
+				},
+				expect: strings.Replace(editTestYAML, "  retry: 1\n", "  retry: 1\n  verbose: true\n", 1),
+			},
+			"insert mapping": {
+				edit: func(f *ast.File) error {
+					return Insert(f, query.New().Key("defaults").Key("limits"), map[string]int{"cpu": 2})
+				},
+				expect: strings.Replace(editTestYAML, "  retry: 1\n", "  retry: 1\n  limits:\n    cpu: 2\n", 1),
+			},
+			"insert sequence entry": {
+				edit: func(f *ast.File) error {
+					return Insert(f, query.New().Key("servers").Index(2), map[string]any{"host": "c.example.com"})
+				},
+				expect: strings.Replace(editTestYAML, "    port: 8080\n", "    port: 8080\n  - host: c.example.com\n", 1),
+			},
+			"insert first sequence entry": {
+				edit: func(f *ast.File) error {
+					return Insert(f, query.New().Key("servers").Index(0), map[string]any{"host": "c.example.com"})
+				},
+				expect: strings.Replace(editTestYAML, "  # primary\n", "  - host: c.example.com\n  # primary\n", 1),
+			},
+			"insert into flow sequence": {
+				edit: func(f *ast.File) error {
+					return Insert(f, query.New().Key("tags").Index(0), "z")
+				},
+				expect: strings.Replace(editTestYAML, "[a, b]", "[z, a, b]", 1),
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				f := parseEditTestYAML(t)
+				if err := test.edit(f); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got := f.String(); got != test.expect {
+					t.Errorf("expect\n%q\nbut got\n%q", test.expect, got)
+				}
+				if _, err := parser.ParseBytes([]byte(f.String()), 0); err != nil {
+					t.Errorf("failed to parse the edited source: %s", err)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			edit     func(*ast.File) error
+			notFound bool
+			expect   string
+		}{
+			"replace missing key": {
+				edit: func(f *ast.File) error {
+					return Replace(f, query.New().Key("servers").Index(0).Key("user"), "root")
+				},
+				notFound: true,
+				expect:   `".servers[0].user" not found`,
+			},
+			"replace through missing key": {
+				edit: func(f *ast.File) error {
+					return Replace(f, query.New().Key("missing").Key("x"), "root")
+				},
+				notFound: true,
+				expect:   `".missing.x" not found`,
+			},
+			"delete out of range": {
+				edit: func(f *ast.File) error {
+					return Delete(f, query.New().Key("servers").Index(2))
+				},
+				notFound: true,
+				expect:   `".servers[2]" not found`,
+			},
+			"delete root": {
+				edit: func(f *ast.File) error {
+					return Delete(f, query.New())
+				},
+				expect: "can not delete the root",
+			},
+			"insert existing key": {
+				edit: func(f *ast.File) error {
+					return Insert(f, query.New().Key("name"), "x")
+				},
+				expect: `key "name" already exists`,
+			},
+			"insert out of range": {
+				edit: func(f *ast.File) error {
+					return Insert(f, query.New().Key("tags").Index(3), "x")
+				},
+				notFound: true,
+				expect:   `".tags[3]" not found`,
+			},
+			"edit through alias": {
+				edit: func(f *ast.File) error {
+					return Replace(f, query.New().Key("backup").Key("retry"), 3)
+				},
+				expect: ".backup.retry: can not edit through alias *defaults",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				f := parseEditTestYAML(t)
+				err := test.edit(f)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := errors.Is(err, query.ErrNotFound); got != test.notFound {
+					t.Errorf("expect ErrNotFound %t but got %s", test.notFound, err)
+				}
+				if got := err.Error(); !strings.Contains(got, test.expect) {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+				if got := f.String(); got != editTestYAML {
+					t.Errorf("source is changed:\n%s", got)
+				}
+			})
+		}
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"log"
 
 	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/parser"
 	"github.com/zoncoen/query-go/v2"
 
 	yamlextractor "github.com/zoncoen/query-go/extractor/yaml"
@@ -30,3 +31,100 @@ func ExampleMapSliceExtractFunc() {
 	// Output:
 	// bar
 }
+
+func ExampleNodeExtractFunc() {
+	b := []byte(`servers:
+  - host: a.example.com
+  - host: b.example.com # fallback
+`)
+	f, err := parser.ParseBytes(b, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	q := query.New(
+		query.CustomExtractFunc(yamlextractor.NodeExtractFunc()),
+	).Key("servers").Index(1).Key("host")
+	got, err := q.Extract(context.Background(), f.Docs[0])
+	if err != nil {
+		log.Fatal(err)
+	}
+	n := got.(*yamlextractor.Node)
+	v, err := n.Value()
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Printf("%s at %d:%d %s\n", v, n.Position().Line, n.Position().Column, n.Comments()[0].String())
+	// Output:
+	// b.example.com at 3:11 # fallback
+}
+
+func ExampleAnnotateSource() {
+	src := []byte(`servers:
+  - host: a.example.com
+  - host: b.example.com
+`)
+	q, err := query.ParseString("$.servers[1].host")
+	if err != nil {
+		log.Fatal(err)
+	}
+	b, err := yamlextractor.AnnotateSource(src, q, false)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fmt.Println(string(b))
+	// Output:
+	//    1 | servers:
+	//    2 |   - host: a.example.com
+	// >  3 |   - host: b.example.com
+	//                  ^
+}
+
+func ExampleReplace() {
+	b := []byte(`# service
+name: app
+timeout: 30 # seconds
+`)
+	f, err := parser.ParseBytes(b, parser.ParseComments)
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := yamlextractor.Replace(f, query.New().Key("timeout"), 60); err != nil {
+		log.Fatal(err)
+	}
+	fmt.Print(f.String())
+	// Output:
+	// # service
+	// name: app
+	// timeout: 60 # seconds
+}
+
+func ExampleStream_Select() {
+	b := []byte(`kind: Service
+metadata:
+  name: web
+---
+kind: Deployment
+metadata:
+  name: web
+`)
+	f, err := parser.ParseBytes(b, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	ctx := context.Background()
+	docs, err := yamlextractor.NewStream(f).Select(ctx, query.New().Key("kind"), "Deployment")
+	if err != nil {
+		log.Fatal(err)
+	}
+	results, err := yamlextractor.ExtractAll(ctx, docs, query.New().Key("metadata").Key("name"))
+	if err != nil {
+		log.Fatal(err)
+	}
+	for _, r := range results {
+		fmt.Printf("document %d: %s\n", r.Index, r.Value)
+	}
+	// Output:
+	// document 1: web
+}
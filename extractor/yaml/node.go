@@ -0,0 +1,403 @@
+package yaml
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/token"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+// Node represents a node of a YAML document parsed by goccy/go-yaml, which
+// keeps the source location lost by decoding. It implements the
+// query.KeyExtractor and query.IndexExtractor interfaces, so a query
+// extracts the *Node of the queried location:
+//
+//	file, err := parser.ParseBytes(b, parser.ParseComments)
+//	v, err := q.Extract(ctx, yaml.NewNode(file.Docs[0]))
+//	n := v.(*yaml.Node)
+//	fmt.Println(n.Position().Line)
+//
+// Anchor and tag nodes are looked through, and an alias node is resolved to
+// the value of its anchor. An alias in the anchor it refers to, which makes
+// an alias cycle, fails the extraction with ErrAliasCycle.
+type Node struct {
+	node ast.Node
+	// head is the head comment of the node, which goccy/go-yaml attaches to
+	// the mapping value node or the sequence instead of the node itself.
+	head *ast.CommentGroupNode
+	doc  *document
+}
+
+// NewNode returns a new Node of n which is the root of the query. A document
+// node is replaced by its body.
+func NewNode(n ast.Node) *Node {
+	if d, ok := n.(*ast.DocumentNode); ok {
+		n = d.Body
+	}
+	return &Node{
+		node: n,
+		doc:  newDocument(n),
+	}
+}
+
+// NodeExtractFunc is a function for query.CustomExtractFunc option to extract values from goccy/go-yaml AST (ast.Node).
+// The extracted value is a *Node. An *ast.File is extracted as a Stream of the documents.
+func NodeExtractFunc() func(query.ExtractFunc) query.ExtractFunc {
+	return func(f query.ExtractFunc) query.ExtractFunc {
+		return func(ctx context.Context, in reflect.Value) (reflect.Value, error) {
+			if in.IsValid() && in.CanInterface() {
+				switch n := in.Interface().(type) {
+				case *ast.File:
+					if n != nil {
+						return f(ctx, reflect.ValueOf(NewStream(n)))
+					}
+				case ast.Node:
+					if !isNil(n) {
+						return f(ctx, reflect.ValueOf(NewNode(n)))
+					}
+				}
+			}
+			return f(ctx, in)
+		}
+	}
+}
+
+func isNil(n ast.Node) bool {
+	v := reflect.ValueOf(n)
+	return v.Kind() == reflect.Pointer && v.IsNil()
+}
+
+// AST returns the AST node of n.
+func (n *Node) AST() ast.Node {
+	return n.node
+}
+
+// Position returns the position of n in the source. The position of a block
+// mapping is the one of the first key.
+func (n *Node) Position() *token.Position {
+	if n.node == nil {
+		return nil
+	}
+	switch x := n.node.(type) {
+	case *ast.MappingNode:
+		if len(x.Values) > 0 {
+			return x.Values[0].Key.GetToken().Position
+		}
+	case *ast.MappingValueNode:
+		return x.Key.GetToken().Position
+	}
+	return n.node.GetToken().Position
+}
+
+// Comments returns the comments attached to n: the head comment, followed by
+// the line comment.
+func (n *Node) Comments() []*ast.CommentGroupNode {
+	var cs []*ast.CommentGroupNode
+	if n.head != nil {
+		cs = append(cs, n.head)
+	}
+	if n.node == nil {
+		return cs
+	}
+	if c := n.node.GetComment(); c != nil {
+		cs = append(cs, c)
+	}
+	return cs
+}
+
+// Decode decodes n into the value pointed to by v with opts. The aliases in
+// n are resolved by the anchors of the document.
+func (n *Node) Decode(v any, opts ...yaml.DecodeOption) error {
+	if n.node == nil {
+		// An empty document has no node.
+		return nil
+	}
+	dec := yaml.NewDecoder(&bytes.Buffer{}, opts...)
+	// Decoding an anchor registers it to the decoder.
+	for _, a := range n.doc.anchorsBefore(n.node) {
+		var discard any
+		if err := dec.DecodeFromNode(a, &discard); err != nil {
+			return err
+		}
+	}
+	return dec.DecodeFromNode(n.node, v)
+}
+
+// Value returns the decoded value of n.
+func (n *Node) Value(opts ...yaml.DecodeOption) (any, error) {
+	var v any
+	if err := n.Decode(&v, opts...); err != nil {
+		return nil, err
+	}
+	return v, nil
+}
+
+// String returns the source text of n.
+func (n *Node) String() string {
+	if n.node == nil {
+		return ""
+	}
+	return n.node.String()
+}
+
+// ExtractByKey implements the query.KeyExtractor interface.
+// The keys merged by merge keys ("<<") are also found, and the explicit keys
+// override the merged ones. If the merge keys have the same key, the first one
+// wins.
+func (n *Node) ExtractByKey(ctx context.Context, key string) (any, error) {
+	node, err := n.doc.resolve(n.node)
+	if err != nil {
+		return nil, err
+	}
+	mv, err := n.doc.lookup(node, key, query.IsCaseInsensitive(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return n.child(mv.Value, mv.GetComment()), nil
+}
+
+// Keys implements the query.KeyLister interface. It returns the keys of the
+// mapping including the merged ones.
+func (n *Node) Keys(_ context.Context) []string {
+	node, err := n.doc.resolve(n.node)
+	if err != nil {
+		return nil
+	}
+	return n.doc.keys(node, map[ast.Node]bool{})
+}
+
+// ExtractByIndex implements the query.IndexExtractor interface.
+// A negative index accesses the sequence from the end like query.Index.
+func (n *Node) ExtractByIndex(_ context.Context, index int) (any, error) {
+	node, err := n.doc.resolve(n.node)
+	if err != nil {
+		return nil, err
+	}
+	seq, ok := node.(*ast.SequenceNode)
+	if !ok {
+		return nil, query.ErrNotFound
+	}
+	if index < 0 {
+		index += len(seq.Values)
+	}
+	if index < 0 || len(seq.Values) <= index {
+		return nil, query.ErrNotFound
+	}
+	var head *ast.CommentGroupNode
+	if index < len(seq.ValueHeadComments) {
+		head = seq.ValueHeadComments[index]
+	}
+	if index == 0 && head == nil && !seq.IsFlowStyle {
+		// goccy/go-yaml attaches the head comment of the first entry to the
+		// sequence.
+		head = seq.GetComment()
+	}
+	return n.child(seq.Values[index], head), nil
+}
+
+func (n *Node) child(node ast.Node, head *ast.CommentGroupNode) *Node {
+	return &Node{
+		node: node,
+		head: head,
+		doc:  n.doc,
+	}
+}
+
+func mappingValues(node ast.Node) []*ast.MappingValueNode {
+	switch x := node.(type) {
+	case *ast.MappingNode:
+		return x.Values
+	case *ast.MappingValueNode:
+		return []*ast.MappingValueNode{x}
+	}
+	return nil
+}
+
+// mapKeyString returns the key as written in the source, without quotes.
+func mapKeyString(k ast.MapKeyNode) string {
+	if s, ok := k.(*ast.StringNode); ok {
+		return s.Value
+	}
+	if tk := k.GetToken(); tk != nil {
+		return tk.Value
+	}
+	return k.String()
+}
+
+// document represents the anchors of a YAML document.
+type document struct {
+	anchors map[string][]*ast.AnchorNode
+	// cyclic holds the aliases which refer to the anchors containing
+	// themselves.
+	cyclic map[*ast.AliasNode]bool
+}
+
+func newDocument(root ast.Node) *document {
+	doc := &document{
+		anchors: map[string][]*ast.AnchorNode{},
+		cyclic:  map[*ast.AliasNode]bool{},
+	}
+	if root == nil {
+		return doc
+	}
+	for _, node := range ast.Filter(ast.AnchorType, root) {
+		a := node.(*ast.AnchorNode)
+		name := a.Name.GetToken().Value
+		doc.anchors[name] = append(doc.anchors[name], a)
+	}
+	// Every alias cycle has an alias which refers to an anchor containing
+	// the alias, since an alias refers to an anchor defined before it.
+	for _, as := range doc.anchors {
+		for _, a := range as {
+			if a.Value == nil {
+				continue
+			}
+			for _, node := range ast.Filter(ast.AliasType, a.Value) {
+				alias := node.(*ast.AliasNode)
+				if doc.anchor(alias) == a {
+					doc.cyclic[alias] = true
+				}
+			}
+		}
+	}
+	return doc
+}
+
+// resolve returns the node which has the contents of node, looking through
+// anchor and tag nodes and resolving alias nodes.
+func (d *document) resolve(node ast.Node) (ast.Node, error) {
+	for {
+		switch x := node.(type) {
+		case *ast.AnchorNode:
+			node = x.Value
+		case *ast.TagNode:
+			node = x.Value
+		case *ast.AliasNode:
+			if d.cyclic[x] {
+				return nil, fmt.Errorf("%w: *%s", ErrAliasCycle, aliasName(x))
+			}
+			a := d.anchor(x)
+			if a == nil {
+				return nil, query.ErrNotFound
+			}
+			node = a.Value
+		default:
+			return node, nil
+		}
+	}
+}
+
+// lookup returns the mapping value of key in node, following merge keys.
+func (d *document) lookup(node ast.Node, key string, ci bool) (*ast.MappingValueNode, error) {
+	mvs := mappingValues(node)
+	for _, mv := range mvs {
+		if mv.Key.IsMergeKey() {
+			continue
+		}
+		k := mapKeyString(mv.Key)
+		if k == key || ci && strings.EqualFold(k, key) {
+			return mv, nil
+		}
+	}
+	for _, mv := range mvs {
+		if !mv.Key.IsMergeKey() {
+			continue
+		}
+		src, err := d.resolve(mv.Value)
+		if err != nil {
+			return nil, err
+		}
+		srcs := []ast.Node{src}
+		if seq, ok := src.(*ast.SequenceNode); ok {
+			srcs = seq.Values
+		}
+		for _, src := range srcs {
+			m, err := d.resolve(src)
+			if err != nil {
+				return nil, err
+			}
+			found, err := d.lookup(m, key, ci)
+			if err == nil {
+				return found, nil
+			}
+			if !errors.Is(err, query.ErrNotFound) {
+				return nil, err
+			}
+		}
+	}
+	return nil, query.ErrNotFound
+}
+
+func (d *document) keys(node ast.Node, visited map[ast.Node]bool) []string {
+	if visited[node] {
+		return nil
+	}
+	visited[node] = true
+	var keys []string
+	for _, mv := range mappingValues(node) {
+		if !mv.Key.IsMergeKey() {
+			keys = append(keys, mapKeyString(mv.Key))
+			continue
+		}
+		src, err := d.resolve(mv.Value)
+		if err != nil {
+			continue
+		}
+		srcs := []ast.Node{src}
+		if seq, ok := src.(*ast.SequenceNode); ok {
+			srcs = seq.Values
+		}
+		for _, src := range srcs {
+			if m, err := d.resolve(src); err == nil {
+				keys = append(keys, d.keys(m, visited)...)
+			}
+		}
+	}
+	return keys
+}
+
+// anchor returns the anchor which the alias refers to: the last one defined
+// before the alias.
+func (d *document) anchor(alias *ast.AliasNode) *ast.AnchorNode {
+	var found *ast.AnchorNode
+	for _, a := range d.anchors[aliasName(alias)] {
+		if offset(a) < offset(alias) {
+			found = a
+		}
+	}
+	return found
+}
+
+func aliasName(alias *ast.AliasNode) string {
+	return alias.Value.GetToken().Value
+}
+
+// anchorsBefore returns the anchors defined before node in the document
+// order.
+func (d *document) anchorsBefore(node ast.Node) []*ast.AnchorNode {
+	var anchors []*ast.AnchorNode
+	for _, as := range d.anchors {
+		for _, a := range as {
+			if offset(a) < offset(node) {
+				anchors = append(anchors, a)
+			}
+		}
+	}
+	slices.SortFunc(anchors, func(a, b *ast.AnchorNode) int {
+		return offset(a) - offset(b)
+	})
+	return anchors
+}
+
+func offset(node ast.Node) int {
+	return node.GetToken().Position.Offset
+}
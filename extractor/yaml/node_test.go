@@ -0,0 +1,316 @@
+package yaml
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+const nodeTestYAML = `# service
+name: app
+defaults: &defaults
+  timeout: 30 # seconds
+servers:
+  - host: a.example.com
+    port: 80
+  # secondary
+  - host: b.example.com
+    port: !!str 8080
+backup: *defaults
+Title: upper
+`
+
+func parseNodeTestYAML(t *testing.T) ast.Node {
+	t.Helper()
+	f, err := parser.ParseBytes([]byte(nodeTestYAML), parser.ParseComments)
+	if err != nil {
+		t.Fatalf("failed to parse: %s", err)
+	}
+	return f.Docs[0]
+}
+
+func TestNodeExtractFunc(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query    *query.Query
+			line     int
+			column   int
+			value    interface{}
+			comments []string
+		}{
+			"key": {
+				query:    query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("name"),
+				line:     2,
+				column:   7,
+				value:    "app",
+				comments: []string{"# service"},
+			},
+			"key (case-insensitive)": {
+				query:  query.New(query.CaseInsensitive(), query.CustomExtractFunc(NodeExtractFunc())).Key("title"),
+				line:   12,
+				column: 8,
+				value:  "upper",
+			},
+			"anchor": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("defaults").Key("timeout"),
+				line:   4,
+				column: 12,
+				value:  uint64(30),
+				comments: []string{
+					"# seconds",
+				},
+			},
+			"index": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("servers").Index(0).Key("host"),
+				line:   6,
+				column: 11,
+				value:  "a.example.com",
+			},
+			"sequence entry": {
+				query:    query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("servers").Index(1),
+				line:     9,
+				column:   5,
+				value:    map[string]interface{}{"host": "b.example.com", "port": "8080"},
+				comments: []string{"# secondary"},
+			},
+			"negative index": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("servers").Index(-1).Key("port"),
+				line:   10,
+				column: 11,
+				value:  "8080",
+			},
+			"alias": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("backup"),
+				line:   11,
+				column: 9,
+				value:  map[string]interface{}{"timeout": uint64(30)},
+			},
+			"through alias": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("backup").Key("timeout"),
+				line:   4,
+				column: 12,
+				value:  uint64(30),
+				comments: []string{
+					"# seconds",
+				},
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				got, err := test.query.Extract(context.Background(), parseNodeTestYAML(t))
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				n, ok := got.(*Node)
+				if !ok {
+					t.Fatalf("expect *Node but got %T", got)
+				}
+				pos := n.Position()
+				if pos.Line != test.line || pos.Column != test.column {
+					t.Errorf("expect %d:%d but got %d:%d", test.line, test.column, pos.Line, pos.Column)
+				}
+				v, err := n.Value()
+				if err != nil {
+					t.Fatalf("failed to decode: %s", err)
+				}
+				if !reflect.DeepEqual(v, test.value) {
+					t.Errorf("expect %#v but got %#v", test.value, v)
+				}
+				var comments []string
+				for _, c := range n.Comments() {
+					comments = append(comments, strings.TrimSpace(c.String()))
+				}
+				if !reflect.DeepEqual(comments, test.comments) {
+					t.Errorf("expect comments %q but got %q", test.comments, comments)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			expect string
+		}{
+			"key not found": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("Name"),
+				expect: `".Name" not found`,
+			},
+			"index out of range": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("servers").Index(2),
+				expect: `".servers[2]" not found`,
+			},
+			"index into a mapping": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("defaults").Index(0),
+				expect: `".defaults[0]" not found`,
+			},
+			"key of a scalar": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("name").Key("foo"),
+				expect: `".name.foo" not found`,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				_, err := test.query.Extract(context.Background(), parseNodeTestYAML(t))
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); !strings.Contains(got, test.expect) {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+}
+
+func TestNode_Value(t *testing.T) {
+	n := NewNode(parseNodeTestYAML(t))
+	if pos := n.Position(); pos.Line != 2 || pos.Column != 1 {
+		t.Errorf("expect 2:1 but got %d:%d", pos.Line, pos.Column)
+	}
+	got, err := n.Value()
+	if err != nil {
+		t.Fatalf("failed to decode: %s", err)
+	}
+	expect := map[string]interface{}{
+		"name":     "app",
+		"defaults": map[string]interface{}{"timeout": uint64(30)},
+		"servers": []interface{}{
+			map[string]interface{}{"host": "a.example.com", "port": uint64(80)},
+			map[string]interface{}{"host": "b.example.com", "port": "8080"},
+		},
+		"backup": map[string]interface{}{"timeout": uint64(30)},
+		"Title":  "upper",
+	}
+	if !reflect.DeepEqual(got, expect) {
+		t.Errorf("expect %#v but got %#v", expect, got)
+	}
+}
+
+func TestNode_MergeKey(t *testing.T) {
+	src := `base: &base
+  timeout: 10
+  retry: 1
+extra: &extra
+  timeout: 20
+  verbose: true
+service: &service
+  <<: [*base, *extra]
+  retry: 3
+child:
+  <<: *service
+  name: child
+inline:
+  <<: {port: 80}
+cycle: &cycle
+  <<: *cycle
+self: &self
+  next: *self
+`
+	f, err := parser.ParseBytes([]byte(src), 0)
+	if err != nil {
+		t.Fatalf("failed to parse: %s", err)
+	}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			line   int
+			expect interface{}
+		}{
+			"explicit key overrides merged one": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("service").Key("retry"),
+				line:   9,
+				expect: uint64(3),
+			},
+			"first merged mapping wins": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("service").Key("timeout"),
+				line:   2,
+				expect: uint64(10),
+			},
+			"merged from the second mapping": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("service").Key("verbose"),
+				line:   6,
+				expect: true,
+			},
+			"nested merge": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("child").Key("timeout"),
+				line:   2,
+				expect: uint64(10),
+			},
+			"nested merge (case-insensitive)": {
+				query:  query.New(query.CaseInsensitive(), query.CustomExtractFunc(NodeExtractFunc())).Key("child").Key("VERBOSE"),
+				line:   6,
+				expect: true,
+			},
+			"inline mapping": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("inline").Key("port"),
+				line:   14,
+				expect: uint64(80),
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				got, err := test.query.Extract(context.Background(), f.Docs[0])
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				n := got.(*Node)
+				if l := n.Position().Line; l != test.line {
+					t.Errorf("expect line %d but got %d", test.line, l)
+				}
+				v, err := n.Value()
+				if err != nil {
+					t.Fatalf("failed to decode: %s", err)
+				}
+				if v != test.expect {
+					t.Errorf("expect %v but got %v", test.expect, v)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query *query.Query
+			cycle bool
+		}{
+			"not found": {
+				query: query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("child").Key("port"),
+			},
+			"merge key is not a key": {
+				query: query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("service").Key("<<"),
+			},
+			"merge cycle": {
+				query: query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("cycle").Key("timeout"),
+				cycle: true,
+			},
+			"alias cycle": {
+				query: query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("self").Key("next").Key("next"),
+				cycle: true,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				_, err := test.query.Extract(context.Background(), f.Docs[0])
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := errors.Is(err, ErrAliasCycle); got != test.cycle {
+					t.Errorf("expect ErrAliasCycle %t but got %s", test.cycle, err)
+				}
+				if got := errors.Is(err, query.ErrNotFound); got == test.cycle {
+					t.Errorf("expect ErrNotFound %t but got %s", !test.cycle, err)
+				}
+			})
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package yaml
+
+// Option represents an option for MapSliceExtractFunc.
+type Option func(*config)
+
+type config struct {
+	positionalIndex bool
+}
+
+func newConfig(opts []Option) *config {
+	c := &config{}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+// PositionalIndex returns the Option to extract the n-th item of a
+// yaml.MapSlice by an index (e.g. "$.matrix[0]") as yaml.MapItem, which has
+// the key and the value of the item. A negative index accesses the items from
+// the end. An index matching a key of the items takes precedence over the
+// position.
+func PositionalIndex() Option {
+	return func(c *config) {
+		c.positionalIndex = true
+	}
+}
@@ -0,0 +1,145 @@
+package yaml
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+// QueryToPath converts q to the goccy/go-yaml path (e.g. "$.a[0].b") which
+// selects the same location. It returns an error if q contains a negative
+// index, an extractor other than query.Key and query.Index, or a key which
+// the path can't select (an empty key, a key starting with a single quote, or
+// a key with a single quote or a backslash which needs to be quoted). The
+// options of q such as query.CaseInsensitive are not converted.
+func QueryToPath(q *query.Query) (*yaml.Path, error) {
+	b := (&yaml.PathBuilder{}).Root()
+	for _, e := range q.Extractors() {
+		switch e := e.(type) {
+		case *query.Key:
+			selector, err := pathSelector(e.Key())
+			if err != nil {
+				return nil, fmt.Errorf("failed to convert %q to YAML path: %w", q.String(), err)
+			}
+			b = b.Child(selector)
+		case *query.Index:
+			if e.Index() < 0 {
+				return nil, fmt.Errorf("failed to convert %q to YAML path: negative index %d is not supported", q.String(), e.Index())
+			}
+			b = b.Index(uint(e.Index()))
+		default:
+			return nil, fmt.Errorf("failed to convert %q to YAML path: unsupported extractor %T", q.String(), e)
+		}
+	}
+	return b.Build(), nil
+}
+
+// pathSelector returns the selector of the YAML path to select key, quoting
+// it if it contains the reserved characters.
+func pathSelector(key string) (string, error) {
+	if key == "" {
+		return "", fmt.Errorf("empty key is not supported")
+	}
+	if key[0] == '\'' {
+		return "", fmt.Errorf("key %q starting with a single quote is not supported", key)
+	}
+	if !strings.ContainsAny(key, ".*[]$") {
+		return key, nil
+	}
+	if strings.ContainsAny(key, `'\`) {
+		return "", fmt.Errorf("key %q containing a single quote or a backslash is not supported", key)
+	}
+	return "'" + key + "'", nil
+}
+
+// PathToQuery converts p to the query which selects the same location.
+// It returns an error if p contains a wildcard ("[*]") or a recursive descent
+// (".."), which have no query equivalents.
+func PathToQuery(p *yaml.Path, opts ...query.Option) (*query.Query, error) {
+	s := p.String()
+	q := query.New(opts...).Root()
+	r := []rune(s)
+	if len(r) == 0 || r[0] != '$' {
+		return nil, fmt.Errorf("invalid YAML path %q: must start with $", s)
+	}
+	for i := 1; i < len(r); {
+		switch r[i] {
+		case '.':
+			i++
+			if i < len(r) && r[i] == '.' {
+				return nil, fmt.Errorf("failed to convert YAML path %q: recursive descent is not supported", s)
+			}
+			if i < len(r) && r[i] == '\'' {
+				key, n, err := parseQuotedPathKey(r[i:])
+				if err != nil {
+					return nil, fmt.Errorf("invalid YAML path %q: %w", s, err)
+				}
+				q = q.Key(key)
+				i += n
+				continue
+			}
+			start := i
+			for i < len(r) && r[i] != '.' && r[i] != '[' {
+				i++
+			}
+			q = q.Key(string(r[start:i]))
+		case '[':
+			end := i + 1
+			for end < len(r) && r[end] != ']' {
+				end++
+			}
+			if end == len(r) {
+				return nil, fmt.Errorf("invalid YAML path %q: unclosed index", s)
+			}
+			index := string(r[i+1 : end])
+			if index == "*" {
+				return nil, fmt.Errorf("failed to convert YAML path %q: wildcard is not supported", s)
+			}
+			n, err := strconv.Atoi(index)
+			if err != nil {
+				return nil, fmt.Errorf("invalid YAML path %q: invalid index %q", s, index)
+			}
+			q = q.Index(n)
+			i = end + 1
+		default:
+			return nil, fmt.Errorf("invalid YAML path %q: unexpected character %q", s, r[i])
+		}
+	}
+	return q, nil
+}
+
+// parseQuotedPathKey parses the key enclosed in single quotes at the start of
+// r, and returns the key and the number of the consumed runes. A backslash
+// escapes the next character like goccy/go-yaml does.
+func parseQuotedPathKey(r []rune) (string, int, error) {
+	var b strings.Builder
+	for i := 1; i < len(r); i++ {
+		switch r[i] {
+		case '\\':
+			i++
+			if i < len(r) {
+				b.WriteRune(r[i])
+			}
+		case '\'':
+			return b.String(), i + 1, nil
+		default:
+			b.WriteRune(r[i])
+		}
+	}
+	return "", 0, fmt.Errorf("unclosed quoted key")
+}
+
+// AnnotateSource returns the snippet of the YAML source src which annotates
+// the location selected by q, converting q by QueryToPath. If colored is true,
+// the snippet is colored with ANSI escape sequences.
+func AnnotateSource(src []byte, q *query.Query, colored bool) ([]byte, error) {
+	p, err := QueryToPath(q)
+	if err != nil {
+		return nil, err
+	}
+	return p.AnnotateSource(src, colored)
+}
@@ -0,0 +1,185 @@
+package yaml
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+type testExtractor struct{}
+
+func (testExtractor) Extract(_ context.Context, v reflect.Value) (reflect.Value, error) {
+	return v, nil
+}
+
+func (testExtractor) String() string {
+	return ".test"
+}
+
+func TestQueryToPath(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			expect string
+		}{
+			"root": {
+				query:  query.New(),
+				expect: "$",
+			},
+			"key and index": {
+				query:  query.New().Key("a").Index(0).Key("b"),
+				expect: "$.a[0].b",
+			},
+			"reserved characters": {
+				query:  query.New().Key("a.b").Key("c[0]").Key("$d"),
+				expect: "$.'a.b'.'c[0]'.'$d'",
+			},
+			"single quote without reserved characters": {
+				query:  query.New().Key("it's"),
+				expect: "$.it's",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				p, err := QueryToPath(test.query)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got := p.String(); got != test.expect {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+				if _, err := yaml.PathString(p.String()); err != nil {
+					t.Errorf("failed to parse %q: %s", p.String(), err)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			expect string
+		}{
+			"negative index": {
+				query:  query.New().Key("a").Index(-1),
+				expect: "negative index -1 is not supported",
+			},
+			"empty key": {
+				query:  query.New().Key(""),
+				expect: "empty key is not supported",
+			},
+			"quoted key": {
+				query:  query.New().Key("'a'"),
+				expect: "starting with a single quote",
+			},
+			"single quote with reserved characters": {
+				query:  query.New().Key("it's.a"),
+				expect: "containing a single quote or a backslash",
+			},
+			"unsupported extractor": {
+				query:  query.New().Append(testExtractor{}),
+				expect: "unsupported extractor",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				_, err := QueryToPath(test.query)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); !strings.Contains(got, test.expect) {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+}
+
+func TestPathToQuery(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			path   string
+			expect *query.Query
+		}{
+			"root": {
+				path:   "$",
+				expect: query.New().Root(),
+			},
+			"key and index": {
+				path:   "$.a[0].b",
+				expect: query.New().Root().Key("a").Index(0).Key("b"),
+			},
+			"quoted key": {
+				path:   `$.'a.b'.'c\'s'`,
+				expect: query.New().Root().Key("a.b").Key("c's"),
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				p, err := yaml.PathString(test.path)
+				if err != nil {
+					t.Fatalf("failed to parse path: %s", err)
+				}
+				got, err := PathToQuery(p)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got.String() != test.expect.String() {
+					t.Errorf("expect %s but got %s", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			path   string
+			expect string
+		}{
+			"wildcard": {
+				path:   "$.a[*].b",
+				expect: "wildcard is not supported",
+			},
+			"recursive descent": {
+				path:   "$..a",
+				expect: "recursive descent is not supported",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				p, err := yaml.PathString(test.path)
+				if err != nil {
+					t.Fatalf("failed to parse path: %s", err)
+				}
+				_, err = PathToQuery(p)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); !strings.Contains(got, test.expect) {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+}
+
+func TestAnnotateSource(t *testing.T) {
+	src := []byte(`a:
+  - b: 1
+  - b: 2
+`)
+	got, err := AnnotateSource(src, query.New().Key("a").Index(1).Key("b"), false)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if !strings.Contains(string(got), ">  3 |   - b: 2") {
+		t.Errorf("unexpected annotation:\n%s", got)
+	}
+}
@@ -0,0 +1,151 @@
+package yaml
+
+import (
+	"context"
+	"errors"
+	"io"
+	"reflect"
+
+	"github.com/goccy/go-yaml"
+	"github.com/goccy/go-yaml/ast"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+// Stream represents a YAML stream which has multiple documents separated by
+// "---". It implements the query.IndexExtractor interface, so that a query
+// addresses the documents as a sequence, e.g. "$[2].metadata.name" extracts
+// the name of the third document as a *Node.
+type Stream struct {
+	docs []*Document
+}
+
+// Document represents a document of a YAML stream.
+type Document struct {
+	// Index is the index of the document in the stream.
+	Index int
+	// Root is the root node of the document.
+	Root *Node
+}
+
+// Result represents a value extracted from a document of a YAML stream.
+type Result struct {
+	// Index is the index of the document in the stream.
+	Index int
+	// Value is the extracted value.
+	Value any
+}
+
+// NewStream returns a new Stream of the documents of f.
+func NewStream(f *ast.File) *Stream {
+	s := &Stream{}
+	for i, doc := range f.Docs {
+		s.docs = append(s.docs, &Document{
+			Index: i,
+			Root:  NewNode(doc),
+		})
+	}
+	return s
+}
+
+// Documents returns the documents of s.
+func (s *Stream) Documents() []*Document {
+	docs := make([]*Document, len(s.docs))
+	copy(docs, s.docs)
+	return docs
+}
+
+// ExtractByIndex implements the query.IndexExtractor interface.
+// A negative index accesses the documents from the end like query.Index.
+func (s *Stream) ExtractByIndex(_ context.Context, index int) (any, error) {
+	i, ok := normalizeIndex(index, len(s.docs))
+	if !ok {
+		return nil, query.ErrNotFound
+	}
+	return s.docs[i].Root, nil
+}
+
+// Select returns the documents of s in which selector extracts the value
+// equal to value, e.g. the documents of Deployments by
+// Select(ctx, query.New().Key("kind"), "Deployment"). The extracted node is
+// decoded into the type of value to compare. The documents in which selector
+// extracts nothing are not selected.
+func (s *Stream) Select(ctx context.Context, selector *query.Query, value any) ([]*Document, error) {
+	var docs []*Document
+	for _, doc := range s.docs {
+		v, err := selector.Extract(ctx, doc.Root)
+		if err != nil {
+			if errors.Is(err, query.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		if matchValue(v, value) {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+func matchValue(v, expect any) bool {
+	n, ok := v.(*Node)
+	if !ok {
+		return reflect.DeepEqual(v, expect)
+	}
+	if expect == nil {
+		got, err := n.Value()
+		return err == nil && got == nil
+	}
+	p := reflect.New(reflect.TypeOf(expect))
+	if err := n.Decode(p.Interface()); err != nil {
+		return false
+	}
+	return reflect.DeepEqual(p.Elem().Interface(), expect)
+}
+
+// Extract extracts the value by q from the root of doc.
+func (doc *Document) Extract(ctx context.Context, q *query.Query) (*Result, error) {
+	v, err := q.Extract(ctx, doc.Root)
+	if err != nil {
+		return nil, err
+	}
+	return &Result{
+		Index: doc.Index,
+		Value: v,
+	}, nil
+}
+
+// ExtractAll extracts the values by q from docs, which are selected by
+// Stream.Select typically. The documents which don't have the value are
+// skipped, and the results have the indexes of the documents.
+func ExtractAll(ctx context.Context, docs []*Document, q *query.Query) ([]*Result, error) {
+	var results []*Result
+	for _, doc := range docs {
+		r, err := doc.Extract(ctx, q)
+		if err != nil {
+			if errors.Is(err, query.ErrNotFound) {
+				continue
+			}
+			return nil, err
+		}
+		results = append(results, r)
+	}
+	return results, nil
+}
+
+// DecodeStream decodes all documents of the YAML stream r into a slice, so
+// that a query addresses the documents as a sequence like Stream.
+func DecodeStream(r io.Reader, opts ...yaml.DecodeOption) ([]any, error) {
+	dec := yaml.NewDecoder(r, opts...)
+	var docs []any
+	for {
+		var v any
+		if err := dec.Decode(&v); err != nil {
+			if errors.Is(err, io.EOF) {
+				return docs, nil
+			}
+			return nil, err
+		}
+		docs = append(docs, v)
+	}
+}
@@ -0,0 +1,188 @@
+package yaml
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/goccy/go-yaml/ast"
+	"github.com/goccy/go-yaml/parser"
+
+	"github.com/zoncoen/query-go/v2"
+)
+
+const streamTestYAML = `kind: Service
+metadata:
+  name: web
+---
+kind: Deployment
+metadata:
+  name: web
+spec:
+  replicas: 3
+---
+kind: Deployment
+metadata:
+  name: worker
+spec:
+  replicas: 1
+---
+`
+
+func parseStreamTestYAML(t *testing.T) *ast.File {
+	t.Helper()
+	f, err := parser.ParseBytes([]byte(streamTestYAML), 0)
+	if err != nil {
+		t.Fatalf("failed to parse: %s", err)
+	}
+	return f
+}
+
+func TestStream_ExtractByIndex(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			expect interface{}
+		}{
+			"document": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Index(2).Key("metadata").Key("name"),
+				expect: "worker",
+			},
+			"negative index": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Index(-2).Key("spec").Key("replicas"),
+				expect: uint64(1),
+			},
+			"empty document": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Index(3),
+				expect: nil,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				got, err := test.query.Extract(context.Background(), parseStreamTestYAML(t))
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				v, err := got.(*Node).Value()
+				if err != nil {
+					t.Fatalf("failed to decode: %s", err)
+				}
+				if v != test.expect {
+					t.Errorf("expect %v but got %v", test.expect, v)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			expect string
+		}{
+			"out of range": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Index(4),
+				expect: `"[4]" not found`,
+			},
+			"key of stream": {
+				query:  query.New(query.CustomExtractFunc(NodeExtractFunc())).Key("kind"),
+				expect: `".kind" not found`,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				_, err := test.query.Extract(context.Background(), parseStreamTestYAML(t))
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); !strings.Contains(got, test.expect) {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+}
+
+func TestStream_Select(t *testing.T) {
+	s := NewStream(parseStreamTestYAML(t))
+	tests := map[string]struct {
+		selector *query.Query
+		value    interface{}
+		query    *query.Query
+		expect   []int
+		values   []interface{}
+	}{
+		"by kind": {
+			selector: query.New().Key("kind"),
+			value:    "Deployment",
+			query:    query.New().Key("metadata").Key("name"),
+			expect:   []int{1, 2},
+			values:   []interface{}{"web", "worker"},
+		},
+		"by integer": {
+			selector: query.New().Key("spec").Key("replicas"),
+			value:    3,
+			query:    query.New().Key("metadata").Key("name"),
+			expect:   []int{1},
+			values:   []interface{}{"web"},
+		},
+		"skip not found": {
+			selector: query.New().Key("metadata").Key("name"),
+			value:    "web",
+			query:    query.New().Key("spec").Key("replicas"),
+			expect:   []int{0, 1},
+			values:   []interface{}{uint64(3)},
+		},
+		"type mismatch": {
+			selector: query.New().Key("kind"),
+			value:    1,
+			query:    query.New(),
+		},
+	}
+	for name, test := range tests {
+		test := test
+		t.Run(name, func(t *testing.T) {
+			docs, err := s.Select(context.Background(), test.selector, test.value)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var indexes []int
+			for _, doc := range docs {
+				indexes = append(indexes, doc.Index)
+			}
+			if !reflect.DeepEqual(indexes, test.expect) {
+				t.Errorf("expect %v but got %v", test.expect, indexes)
+			}
+			results, err := ExtractAll(context.Background(), docs, test.query)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			var values []interface{}
+			for _, r := range results {
+				v, err := r.Value.(*Node).Value()
+				if err != nil {
+					t.Fatalf("failed to decode: %s", err)
+				}
+				values = append(values, v)
+			}
+			if !reflect.DeepEqual(values, test.values) {
+				t.Errorf("expect %v but got %v", test.values, values)
+			}
+		})
+	}
+}
+
+func TestDecodeStream(t *testing.T) {
+	docs, err := DecodeStream(strings.NewReader(streamTestYAML))
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	got, err := query.New().Index(1).Key("metadata").Key("name").Extract(context.Background(), docs)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if expect := "web"; got != expect {
+		t.Errorf("expect %v but got %v", expect, got)
+	}
+}
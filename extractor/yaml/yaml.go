@@ -1,11 +1,16 @@
 /*
-Package yaml provides a function to extract values from yaml.MapSlice.
+Package yaml provides functions to extract values from yaml.MapSlice and
+goccy/go-yaml AST nodes.
 */
 package yaml
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"reflect"
+	"strconv"
 	"strings"
 
 	"github.com/goccy/go-yaml"
@@ -16,7 +21,14 @@ import (
 var mapSliceType = reflect.TypeOf(yaml.MapSlice{})
 
 // MapSliceExtractFunc is a function for query.CustomExtractFunc option to extract values from yaml.MapSlice.
-func MapSliceExtractFunc() func(query.ExtractFunc) query.ExtractFunc {
+//
+// A key matches the string keys of the items first, and then the integer,
+// float, boolean and null keys written as the key (e.g. "1", "true" and
+// "null"). An index matches the integer keys, then the string keys written as
+// the index (goccy/go-yaml decodes the keys of nested mappings into strings),
+// and then the position of the items if PositionalIndex is given.
+func MapSliceExtractFunc(opts ...Option) func(query.ExtractFunc) query.ExtractFunc {
+	c := newConfig(opts)
 	return func(f query.ExtractFunc) query.ExtractFunc {
 		return func(ctx context.Context, in reflect.Value) (reflect.Value, error) {
 			v := in
@@ -37,6 +49,7 @@ func MapSliceExtractFunc() func(query.ExtractFunc) query.ExtractFunc {
 						if ok {
 							return f(ctx, reflect.ValueOf(&keyExtractor{
 								v: s,
+								c: c,
 							}))
 						}
 					}
@@ -47,26 +60,199 @@ func MapSliceExtractFunc() func(query.ExtractFunc) query.ExtractFunc {
 	}
 }
 
+// ErrAliasCycle is the error returned when a query follows an alias which
+// refers to the anchor containing the alias, or a merge key which merges the
+// mapping containing the merge key.
+var ErrAliasCycle = errors.New("alias cycle detected")
+
+// mergeKey is the key of the YAML merge key.
+const mergeKey = "<<"
+
 type keyExtractor struct {
 	v yaml.MapSlice
+	c *config
 }
 
 // ExtractByKey implements the query.KeyExtractor interface.
+//
+// The last item wins if the key is duplicated, as goccy/go-yaml decodes a
+// mapping into a map, and an exact match wins over a case-insensitive match.
+// Since goccy/go-yaml decodes a merge key into the merged items at the
+// position of the merge key, an explicit key following the merge key
+// overrides the merged one. The items of a "<<" item which has mappings
+// (e.g. a yaml.MapSlice built by hand) are found unless the key is found in
+// the explicit items, and the first merged mapping wins.
 func (e *keyExtractor) ExtractByKey(ctx context.Context, key string) (any, error) {
-	ci := query.IsCaseInsensitive(ctx)
-	if ci {
-		key = strings.ToLower(key)
-	}
-	for _, i := range e.v {
-		k, ok := i.Key.(string)
-		if ok {
-			if ci {
-				k = strings.ToLower(k)
+	v, err := lookupMapSlice(e.v, func(k any) bool {
+		return k == key
+	}, map[*yaml.MapItem]bool{})
+	if !errors.Is(err, query.ErrNotFound) {
+		return v, err
+	}
+	if query.IsCaseInsensitive(ctx) {
+		v, err := lookupMapSlice(e.v, func(k any) bool {
+			s, ok := k.(string)
+			return ok && strings.EqualFold(s, key)
+		}, map[*yaml.MapItem]bool{})
+		if !errors.Is(err, query.ErrNotFound) {
+			return v, err
+		}
+	}
+	return lookupMapSlice(e.v, func(k any) bool {
+		s, ok := scalarKeyString(k)
+		return ok && s == key
+	}, map[*yaml.MapItem]bool{})
+}
+
+// ExtractByIndex implements the query.IndexExtractor interface.
+func (e *keyExtractor) ExtractByIndex(_ context.Context, index int) (any, error) {
+	v, err := lookupMapSlice(e.v, func(k any) bool {
+		i, ok := intKey(k)
+		return ok && i == int64(index)
+	}, map[*yaml.MapItem]bool{})
+	if !errors.Is(err, query.ErrNotFound) {
+		return v, err
+	}
+	key := strconv.Itoa(index)
+	v, err = lookupMapSlice(e.v, func(k any) bool {
+		return k == key
+	}, map[*yaml.MapItem]bool{})
+	if !errors.Is(err, query.ErrNotFound) || !e.c.positionalIndex {
+		return v, err
+	}
+	if index < 0 {
+		index += len(e.v)
+	}
+	if index < 0 || len(e.v) <= index {
+		return nil, query.ErrNotFound
+	}
+	return e.v[index], nil
+}
+
+// Keys implements the query.KeyLister interface. It returns the keys of the
+// items including the merged ones.
+func (e *keyExtractor) Keys(_ context.Context) []string {
+	return mapSliceKeys(e.v, map[*yaml.MapItem]bool{})
+}
+
+func mapSliceKeys(s yaml.MapSlice, visited map[*yaml.MapItem]bool) []string {
+	if len(s) == 0 || visited[&s[0]] {
+		return nil
+	}
+	visited[&s[0]] = true
+	var keys []string
+	for _, i := range s {
+		if i.Key == mergeKey {
+			if srcs, ok := mergeSources(i.Value); ok {
+				for _, src := range srcs {
+					keys = append(keys, mapSliceKeys(src, visited)...)
+				}
+				continue
 			}
-			if key == k {
-				return i.Value, nil
+		}
+		if k, ok := i.Key.(string); ok {
+			keys = append(keys, k)
+		} else if k, ok := scalarKeyString(i.Key); ok {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+func lookupMapSlice(s yaml.MapSlice, match func(any) bool, visited map[*yaml.MapItem]bool) (any, error) {
+	if len(s) == 0 {
+		return nil, query.ErrNotFound
+	}
+	if visited[&s[0]] {
+		return nil, fmt.Errorf("%w: %s", ErrAliasCycle, mergeKey)
+	}
+	visited[&s[0]] = true
+	defer delete(visited, &s[0])
+
+	var (
+		found any
+		ok    bool
+		srcs  []yaml.MapSlice
+	)
+	for _, i := range s {
+		if i.Key == mergeKey {
+			if ms, isMerge := mergeSources(i.Value); isMerge {
+				srcs = append(srcs, ms...)
+				continue
 			}
 		}
+		if match(i.Key) {
+			found, ok = i.Value, true
+		}
+	}
+	if ok {
+		return found, nil
+	}
+	for _, src := range srcs {
+		v, err := lookupMapSlice(src, match, visited)
+		if err == nil {
+			return v, nil
+		}
+		if !errors.Is(err, query.ErrNotFound) {
+			return nil, err
+		}
 	}
 	return nil, query.ErrNotFound
 }
+
+// scalarKeyString returns the YAML representation of a non-string scalar key.
+func scalarKeyString(k any) (string, bool) {
+	if k == nil {
+		return "null", true
+	}
+	if i, ok := intKey(k); ok {
+		return strconv.FormatInt(i, 10), true
+	}
+	v := reflect.ValueOf(k)
+	switch v.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return strconv.FormatUint(v.Uint(), 10), true
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'g', -1, v.Type().Bits()), true
+	case reflect.Bool:
+		return strconv.FormatBool(v.Bool()), true
+	}
+	return "", false
+}
+
+// intKey returns the value of an integer key which fits in int64.
+func intKey(k any) (int64, bool) {
+	v := reflect.ValueOf(k)
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int(), true
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		if u := v.Uint(); u <= math.MaxInt64 {
+			return int64(u), true
+		}
+	}
+	return 0, false
+}
+
+// mergeSources returns the mappings merged by a merge key which has v.
+func mergeSources(v any) ([]yaml.MapSlice, bool) {
+	switch x := v.(type) {
+	case yaml.MapSlice:
+		return []yaml.MapSlice{x}, true
+	case *yaml.MapSlice:
+		if x != nil {
+			return []yaml.MapSlice{*x}, true
+		}
+	case []any:
+		srcs := make([]yaml.MapSlice, 0, len(x))
+		for _, e := range x {
+			ms, ok := mergeSources(e)
+			if !ok || len(ms) != 1 {
+				return nil, false
+			}
+			srcs = append(srcs, ms[0])
+		}
+		return srcs, true
+	}
+	return nil, false
+}
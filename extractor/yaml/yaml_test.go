@@ -2,6 +2,8 @@ package yaml
 
 import (
 	"context"
+	"errors"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -42,6 +44,56 @@ func TestMapSliceExtractFunc(t *testing.T) {
 				},
 				expect: "aaa",
 			},
+			"yaml.MapSlice (duplicate key)": {
+				query: query.New(
+					query.CustomExtractFunc(MapSliceExtractFunc()),
+				).Key("foo"),
+				v: yaml.MapSlice{
+					yaml.MapItem{
+						Key:   "foo",
+						Value: "aaa",
+					},
+					yaml.MapItem{
+						Key:   "foo",
+						Value: "bbb",
+					},
+				},
+				expect: "bbb",
+			},
+			"yaml.MapSlice (case-insensitive, duplicate key)": {
+				query: query.New(
+					query.CaseInsensitive(),
+					query.CustomExtractFunc(MapSliceExtractFunc()),
+				).Key("foo"),
+				v: yaml.MapSlice{
+					yaml.MapItem{
+						Key:   "FOO",
+						Value: "aaa",
+					},
+					yaml.MapItem{
+						Key:   "Foo",
+						Value: "bbb",
+					},
+				},
+				expect: "bbb",
+			},
+			"yaml.MapSlice (case-insensitive, exact match first)": {
+				query: query.New(
+					query.CaseInsensitive(),
+					query.CustomExtractFunc(MapSliceExtractFunc()),
+				).Key("foo"),
+				v: yaml.MapSlice{
+					yaml.MapItem{
+						Key:   "foo",
+						Value: "aaa",
+					},
+					yaml.MapItem{
+						Key:   "Foo",
+						Value: "bbb",
+					},
+				},
+				expect: "aaa",
+			},
 			"[]interface{yaml.MapSlice}": {
 				query: query.New(
 					query.CustomExtractFunc(MapSliceExtractFunc()),
@@ -139,3 +191,229 @@ func TestMapSliceExtractFunc(t *testing.T) {
 		}
 	})
 }
+
+func TestMapSliceExtractFunc_MergeKey(t *testing.T) {
+	base := yaml.MapSlice{
+		{Key: "timeout", Value: 10},
+		{Key: "retry", Value: 1},
+	}
+	extra := yaml.MapSlice{
+		{Key: "timeout", Value: 20},
+		{Key: "verbose", Value: true},
+	}
+	cycle := yaml.MapSlice{
+		{Key: "name", Value: "cycle"},
+		{Key: "<<", Value: nil},
+	}
+	cycle[1].Value = cycle
+	q := query.New(query.CustomExtractFunc(MapSliceExtractFunc()))
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			v      interface{}
+			expect interface{}
+		}{
+			"explicit key overrides merged one": {
+				query: q.Key("retry"),
+				v: yaml.MapSlice{
+					{Key: "<<", Value: base},
+					{Key: "retry", Value: 3},
+				},
+				expect: 3,
+			},
+			"first merged mapping wins": {
+				query: q.Key("timeout"),
+				v: yaml.MapSlice{
+					{Key: "<<", Value: []interface{}{base, extra}},
+				},
+				expect: 10,
+			},
+			"nested merge": {
+				query: q.Key("verbose"),
+				v: yaml.MapSlice{
+					{Key: "<<", Value: yaml.MapSlice{
+						{Key: "<<", Value: &extra},
+					}},
+				},
+				expect: true,
+			},
+			"flattened by the decoder": {
+				query: q.Key("timeout"),
+				v: func() interface{} {
+					var v interface{}
+					if err := yaml.UnmarshalWithOptions([]byte("defaults: &defaults {timeout: 10, retry: 1}\nservice:\n  <<: *defaults\n  timeout: 20\n"), &v, yaml.UseOrderedMap()); err != nil {
+						t.Fatal(err)
+					}
+					return v.(yaml.MapSlice)[1].Value
+				}(),
+				expect: uint64(20),
+			},
+			"flattened by the decoder (merge key last)": {
+				query: q.Key("retry"),
+				v: func() interface{} {
+					var v interface{}
+					if err := yaml.UnmarshalWithOptions([]byte("defaults: &defaults {timeout: 10, retry: 1}\nservice:\n  retry: 3\n  <<: *defaults\n"), &v, yaml.UseOrderedMap()); err != nil {
+						t.Fatal(err)
+					}
+					return v.(yaml.MapSlice)[1].Value
+				}(),
+				expect: uint64(1),
+			},
+			"merge key which is not a mapping": {
+				query: q.Key("<<"),
+				v: yaml.MapSlice{
+					{Key: "<<", Value: "value"},
+				},
+				expect: "value",
+			},
+			"cycle is not followed if found": {
+				query:  q.Key("name"),
+				v:      cycle,
+				expect: "cycle",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				got, err := test.query.Extract(context.Background(), test.v)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expect {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		_, err := q.Key("timeout").Extract(context.Background(), cycle)
+		if !errors.Is(err, ErrAliasCycle) {
+			t.Fatalf("expect ErrAliasCycle but got %v", err)
+		}
+	})
+}
+
+func TestMapSliceExtractFunc_NonStringKey(t *testing.T) {
+	v := yaml.MapSlice{
+		{Key: "1", Value: "string one"},
+		{Key: uint64(1), Value: "one"},
+		{Key: 2, Value: "two"},
+		{Key: "3", Value: "string three"},
+		{Key: true, Value: "true"},
+		{Key: nil, Value: "null"},
+		{Key: 1.5, Value: "float"},
+		{Key: "matrix", Value: yaml.MapSlice{
+			{Key: "os", Value: "linux"},
+			{Key: "arch", Value: "amd64"},
+		}},
+	}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			expect interface{}
+		}{
+			"string key wins": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc())).Key("1"),
+				expect: "string one",
+			},
+			"integer key": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc())).Key("2"),
+				expect: "two",
+			},
+			"bool key": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc())).Key("true"),
+				expect: "true",
+			},
+			"null key": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc())).Key("null"),
+				expect: "null",
+			},
+			"float key": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc())).Key("1.5"),
+				expect: "float",
+			},
+			"index matches integer key": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc())).Index(1),
+				expect: "one",
+			},
+			"index matches string key": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc())).Index(3),
+				expect: "string three",
+			},
+			"key wins over position": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc(PositionalIndex()))).Index(2),
+				expect: "two",
+			},
+			"position": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc(PositionalIndex()))).Key("matrix").Index(0),
+				expect: yaml.MapItem{Key: "os", Value: "linux"},
+			},
+			"negative position": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc(PositionalIndex()))).Key("matrix").Index(-1).Key("Value"),
+				expect: "amd64",
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				got, err := test.query.Extract(context.Background(), v)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expect {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *query.Query
+			expect string
+		}{
+			"bool key is case-sensitive": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc())).Key("True"),
+				expect: `".True" not found`,
+			},
+			"position without the option": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc())).Key("matrix").Index(0),
+				expect: `".matrix[0]" not found`,
+			},
+			"position out of range": {
+				query:  query.New(query.CustomExtractFunc(MapSliceExtractFunc(PositionalIndex()))).Key("matrix").Index(2),
+				expect: `".matrix[2]" not found`,
+			},
+		}
+		for name, test := range tests {
+			test := test
+			t.Run(name, func(t *testing.T) {
+				_, err := test.query.Extract(context.Background(), v)
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); !strings.Contains(got, test.expect) {
+					t.Errorf("expect %v but got %v", test.expect, got)
+				}
+			})
+		}
+	})
+}
+
+func TestMapSliceExtractFunc_Suggestions(t *testing.T) {
+	v := yaml.MapSlice{
+		{Key: "<<", Value: yaml.MapSlice{{Key: "timeout", Value: 10}}},
+		{Key: "name", Value: "app"},
+		{Key: 1, Value: "one"},
+	}
+	_, err := query.New(query.Suggestions(), query.CustomExtractFunc(MapSliceExtractFunc())).Key("timeot").Extract(context.Background(), v)
+	var nfe *query.NotFoundError
+	if !errors.As(err, &nfe) {
+		t.Fatalf("expected *query.NotFoundError but got %v", err)
+	}
+	if expect := []string{"1", "name", "timeout"}; !reflect.DeepEqual(nfe.Available, expect) {
+		t.Errorf("expect %v but got %v", expect, nfe.Available)
+	}
+	if expect := []string{"timeout"}; !reflect.DeepEqual(nfe.Suggestions, expect) {
+		t.Errorf("expect %v but got %v", expect, nfe.Suggestions)
+	}
+}
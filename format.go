@@ -0,0 +1,70 @@
+package query
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/zoncoen/query-go/v2/ast"
+)
+
+// RootStyle represents how Query.Format writes the root operator $.
+type RootStyle int
+
+const (
+	// RootAsIs writes "$" only if the query has the explicit root.
+	RootAsIs RootStyle = iota
+	// RootAlways writes "$" at the beginning of every query.
+	RootAlways
+	// RootNever omits "$" even if the query has the explicit root.
+	RootNever
+)
+
+// FormatStyle represents the style of the string representation of a query
+// returned by Query.Format. The zero value is the style of Query.String.
+type FormatStyle struct {
+	// Bracket writes all keys in the bracket notation like "['key']" instead
+	// of the dot notation like ".key".
+	Bracket bool
+	// DoubleQuote quotes the keys in the bracket notation with double quotes
+	// like `["key"]`.
+	DoubleQuote bool
+	// Root controls the root operator $.
+	Root RootStyle
+}
+
+// Format returns q as string in style. The result is parseable into the
+// query equivalent to q, except that RootNever drops the explicit root.
+// It returns an error if q has an extractor other than Key and Index (e.g. a
+// token of ParseJSONPointer), since ParseString does not parse its string
+// representation back into the same extractor.
+func (q *Query) Format(style FormatStyle) (string, error) {
+	var b strings.Builder
+	var root bool
+	switch style.Root {
+	case RootAsIs:
+		root = q != nil && q.hasExplicitRoot
+	case RootAlways:
+		root = true
+	}
+	if root {
+		b.WriteString("$")
+	}
+	if q == nil {
+		return b.String(), nil
+	}
+	c := &ast.PrintConfig{
+		Bracket:     style.Bracket,
+		DoubleQuote: style.DoubleQuote,
+	}
+	for i, e := range q.extractors {
+		switch e := e.(type) {
+		case *Key:
+			b.WriteString(c.Sprint(&ast.Selector{Sel: e.key}))
+		case *Index:
+			b.WriteString(c.Sprint(&ast.Index{Index: e.index}))
+		default:
+			return "", fmt.Errorf("can not format %q: unsupported extractor %T at %s", q.String(), e, q.prefixString(i+1))
+		}
+	}
+	return b.String(), nil
+}
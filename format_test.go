@@ -0,0 +1,112 @@
+package query
+
+import "testing"
+
+func TestQuery_Format(t *testing.T) {
+	q := New().Key("a").Index(0).Key("b.c").Key(`it's "quoted"`)
+	tests := map[string]struct {
+		query  *Query
+		style  FormatStyle
+		expect string
+	}{
+		"default": {
+			query:  q,
+			expect: `.a[0]['b.c']['it\'s "quoted"']`,
+		},
+		"bracket": {
+			query:  q,
+			style:  FormatStyle{Bracket: true},
+			expect: `['a'][0]['b.c']['it\'s "quoted"']`,
+		},
+		"double quote": {
+			query:  q,
+			style:  FormatStyle{DoubleQuote: true},
+			expect: `.a[0]["b.c"]["it's \"quoted\""]`,
+		},
+		"bracket and double quote": {
+			query:  q,
+			style:  FormatStyle{Bracket: true, DoubleQuote: true},
+			expect: `["a"][0]["b.c"]["it's \"quoted\""]`,
+		},
+		"root always": {
+			query:  q,
+			style:  FormatStyle{Root: RootAlways},
+			expect: `$.a[0]['b.c']['it\'s "quoted"']`,
+		},
+		"root as is": {
+			query:  q.Root(),
+			expect: `$.a[0]['b.c']['it\'s "quoted"']`,
+		},
+		"root never": {
+			query:  q.Root(),
+			style:  FormatStyle{Root: RootNever, Bracket: true},
+			expect: `['a'][0]['b.c']['it\'s "quoted"']`,
+		},
+		"nil": {
+			query:  nil,
+			style:  FormatStyle{Root: RootAlways},
+			expect: `$`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := test.query.Format(test.style)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if got != test.expect {
+				t.Fatalf("expect %s but got %s", test.expect, got)
+			}
+			if test.query == nil {
+				return
+			}
+			parsed, err := ParseString(got)
+			if err != nil {
+				t.Fatalf("failed to parse: %s", err)
+			}
+			expect := test.query
+			switch test.style.Root {
+			case RootAlways:
+				expect = expect.Root()
+			case RootNever:
+				expect = New().Append(test.query.Extractors()...)
+			}
+			if parsed.String() != expect.String() {
+				t.Errorf("expect %s but got %s", expect, parsed)
+			}
+		})
+	}
+}
+
+func TestQuery_Format_Failure(t *testing.T) {
+	tests := map[string]struct {
+		query  *Query
+		expect string
+	}{
+		"custom extractor": {
+			query:  New().Key("a").Append(extractorFunc(nil)),
+			expect: `can not format ".a": unsupported extractor query.extractorFunc at .a`,
+		},
+		"JSON Pointer token": {
+			query: func() *Query {
+				q, err := ParseJSONPointer("/a/0")
+				if err != nil {
+					t.Fatal(err)
+				}
+				return q
+			}(),
+			expect: `can not format ".a[0]": unsupported extractor *query.pointerToken at .a[0]`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.query.Format(FormatStyle{})
+			if err == nil {
+				t.Fatal("no error")
+			}
+			if got := err.Error(); got != test.expect {
+				t.Errorf("expect %q but got %q", test.expect, got)
+			}
+		})
+	}
+}
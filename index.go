@@ -34,11 +34,13 @@ type IndexExtractor interface {
 // implementation.
 type Index struct {
 	index int
+	trace bool
 }
 
 // Extract extracts the value from v by index, passing ctx to
 // v.ExtractByIndex if v implements the IndexExtractor interface. It returns
-// ErrNotFound (possibly wrapped) when the index is absent.
+// ErrNotFound (possibly wrapped) when the index is absent, and a
+// *TypeMismatchError when v is neither a sequence nor a map with integer keys.
 func (e *Index) Extract(ctx context.Context, v reflect.Value) (reflect.Value, error) {
 	// CanInterface is required: values obtained from unexported fields are
 	// read-only and Interface would panic on them.
@@ -48,15 +50,17 @@ func (e *Index) Extract(ctx context.Context, v reflect.Value) (reflect.Value, er
 			if err != nil {
 				return reflect.Value{}, err
 			}
+			e.traceResolution(ctx, ResolutionIndexExtractor)
 			return reflect.ValueOf(x), nil
 		}
 	}
-	return e.extract(v)
+	return e.extract(ctx, v)
 }
 
-func (e *Index) extract(v reflect.Value) (reflect.Value, error) {
+func (e *Index) extract(ctx context.Context, v reflect.Value) (reflect.Value, error) {
 	v = elem(v)
 	switch v.Kind() {
+	case reflect.Invalid:
 	case reflect.Slice, reflect.Array:
 		i := e.index
 		if i < 0 {
@@ -65,22 +69,34 @@ func (e *Index) extract(v reflect.Value) (reflect.Value, error) {
 			i += v.Len()
 		}
 		if 0 <= i && i < v.Len() {
+			e.traceResolution(ctx, ResolutionSequenceIndex)
 			return v.Index(i), nil
 		}
 	case reflect.Map:
 		// An index into a map is the literal map key: maps have no order,
 		// so negative indices are not normalized.
+		if !isIndexableMapKey(v.Type().Key()) {
+			return reflect.Value{}, typeMismatch(v, reflect.Slice, reflect.Array, reflect.Map)
+		}
 		key, ok := e.mapKey(v.Type().Key())
 		if !ok {
 			break
 		}
 		if x := v.MapIndex(key); x.IsValid() {
+			e.traceResolution(ctx, ResolutionMapKey)
 			return x, nil
 		}
+	default:
+		return reflect.Value{}, typeMismatch(v, reflect.Slice, reflect.Array, reflect.Map)
 	}
 	return reflect.Value{}, ErrNotFound
 }
 
+// Index returns the index of e.
+func (e *Index) Index() int {
+	return e.index
+}
+
 // mapKey converts the index to a map key of type kt. It reports false when
 // kt cannot hold the index: a non-integer key type, or an integer type whose
 // range the index does not fit in (a silent Convert would truncate and match
@@ -108,6 +124,19 @@ func (e *Index) mapKey(kt reflect.Type) (reflect.Value, bool) {
 	return reflect.Value{}, false
 }
 
+// isIndexableMapKey reports whether the maps with the key type kt can be
+// accessed by an index.
+func isIndexableMapKey(kt reflect.Type) bool {
+	switch kt.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return true
+	case reflect.Interface:
+		return kt.NumMethod() == 0
+	}
+	return false
+}
+
 // String returns e as string.
 func (e *Index) String() string {
 	return fmt.Sprintf("[%d]", e.index)
@@ -192,3 +192,72 @@ func TestIndex_Extract(t *testing.T) {
 		}
 	})
 }
+
+func TestIndex_Extract_TypeMismatch(t *testing.T) {
+	tests := map[string]struct {
+		v        any
+		mismatch bool
+	}{
+		"string": {
+			v:        "abc",
+			mismatch: true,
+		},
+		"struct": {
+			v:        struct{}{},
+			mismatch: true,
+		},
+		"string-keyed map": {
+			v:        map[string]string{"0": "value"},
+			mismatch: true,
+		},
+		"float-keyed map": {
+			v:        map[float64]string{0: "value"},
+			mismatch: true,
+		},
+		"nil": {
+			v: nil,
+		},
+		"index overflows the map key type": {
+			v: map[int8]string{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &Index{index: 300}
+			_, err := e.Extract(context.Background(), reflect.ValueOf(test.v))
+			if !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound but got: %v", err)
+			}
+			if got := errors.Is(err, ErrTypeMismatch); got != test.mismatch {
+				t.Fatalf("expected errors.Is(err, ErrTypeMismatch) to be %t but got %t", test.mismatch, got)
+			}
+		})
+	}
+}
+
+func TestIndex_Index(t *testing.T) {
+	tests := map[string]struct {
+		index int
+	}{
+		"zero": {
+			index: 0,
+		},
+		"positive": {
+			index: 3,
+		},
+		"negative": {
+			index: -1,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			i, ok := New().Index(test.index).Extractors()[0].(*Index)
+			if !ok {
+				t.Fatal("expected *Index")
+			}
+			if got := i.Index(); got != test.index {
+				t.Errorf("expect %d but got %d", test.index, got)
+			}
+		})
+	}
+}
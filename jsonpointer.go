@@ -0,0 +1,165 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// ParseJSONPointer parses a JSON Pointer (RFC 6901) s like "/items/0/name"
+// and returns the corresponding Query.
+//
+// A reference token is unescaped ("~1" to "/" and "~0" to "~") into a key,
+// except that a token which is an array index (e.g. "0" but not "01") is an
+// index of a sequence and a key of the others, which is decided at extraction
+// time. Note that the string representation of the token is the index form
+// like "[0]", which ParseString parses into an index that does not extract
+// the key "0" of a map. So Query.MarshalText and Query.Format return an error
+// for such a query; use Query.JSONPointer to get the source back.
+func ParseJSONPointer(s string, opts ...Option) (*Query, error) {
+	q := New(opts...)
+	if s == "" {
+		return q, nil
+	}
+	if !strings.HasPrefix(s, "/") {
+		return nil, fmt.Errorf("invalid JSON Pointer %q: must be empty or start with \"/\"", s)
+	}
+	for _, tok := range strings.Split(s[1:], "/") {
+		key, err := unescapeJSONPointerToken(tok)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JSON Pointer %q: %w", s, err)
+		}
+		if i, ok := jsonPointerArrayIndex(key); ok {
+			q = q.Append(&pointerToken{
+				key:   q.newKey(key),
+				index: q.newIndex(i),
+			})
+			continue
+		}
+		q = q.Key(key)
+	}
+	return q, nil
+}
+
+// JSONPointer returns q as JSON Pointer (RFC 6901). It returns an error if q
+// can not be expressed as JSON Pointer: a negative index, or an extractor
+// other than Key and Index. The explicit root is ignored since JSON Pointer
+// always starts from the root.
+func (q *Query) JSONPointer() (string, error) {
+	if q == nil {
+		return "", nil
+	}
+	var b strings.Builder
+	for i, e := range q.extractors {
+		b.WriteString("/")
+		switch e := e.(type) {
+		case *Key:
+			b.WriteString(escapeJSONPointerToken(e.key))
+		case *Index:
+			if e.index < 0 {
+				return "", fmt.Errorf("can not express %q as JSON Pointer: negative index at %s", q.String(), q.prefixString(i+1))
+			}
+			b.WriteString(strconv.Itoa(e.index))
+		case *pointerToken:
+			b.WriteString(strconv.Itoa(e.index.index))
+		default:
+			return "", fmt.Errorf("can not express %q as JSON Pointer: unsupported extractor %T at %s", q.String(), e, q.prefixString(i+1))
+		}
+	}
+	return b.String(), nil
+}
+
+func unescapeJSONPointerToken(tok string) (string, error) {
+	if !strings.Contains(tok, "~") {
+		return tok, nil
+	}
+	var b strings.Builder
+	for i := 0; i < len(tok); i++ {
+		if tok[i] != '~' {
+			b.WriteByte(tok[i])
+			continue
+		}
+		if i+1 < len(tok) {
+			switch tok[i+1] {
+			case '0':
+				b.WriteByte('~')
+				i++
+				continue
+			case '1':
+				b.WriteByte('/')
+				i++
+				continue
+			}
+		}
+		return "", fmt.Errorf("invalid escape sequence in %q", tok)
+	}
+	return b.String(), nil
+}
+
+func escapeJSONPointerToken(key string) string {
+	return strings.NewReplacer("~", "~0", "/", "~1").Replace(key)
+}
+
+// jsonPointerArrayIndex returns the index if tok is an array index of JSON
+// Pointer: "0" or digits without a leading zero.
+func jsonPointerArrayIndex(tok string) (int, bool) {
+	if tok == "" || len(tok) > 1 && tok[0] == '0' {
+		return 0, false
+	}
+	for _, ch := range tok {
+		if ch < '0' || '9' < ch {
+			return 0, false
+		}
+	}
+	i, err := strconv.Atoi(tok)
+	return i, err == nil
+}
+
+// pointerToken represents an extractor of a reference token of JSON Pointer
+// which is an array index. It extracts by index from a sequence and by key
+// from the others.
+type pointerToken struct {
+	key   *Key
+	index *Index
+}
+
+// Extract extracts the value from v by index if v is a sequence (or a map
+// with integer keys, or a value implementing only IndexExtractor), and by key
+// otherwise. If v implements both KeyExtractor and IndexExtractor (e.g. a YAML
+// node which may be a mapping or a sequence), the key is tried first.
+func (e *pointerToken) Extract(ctx context.Context, v reflect.Value) (reflect.Value, error) {
+	if v.IsValid() && v.CanInterface() {
+		_, isKey := v.Interface().(KeyExtractor)
+		_, isIndex := v.Interface().(IndexExtractor)
+		switch {
+		case isKey && isIndex:
+			x, err := e.key.Extract(ctx, v)
+			if !errors.Is(err, ErrNotFound) {
+				return x, err
+			}
+			return e.index.Extract(ctx, v)
+		case isKey:
+			return e.key.Extract(ctx, v)
+		case isIndex:
+			return e.index.Extract(ctx, v)
+		}
+	}
+	switch x := elem(v); x.Kind() {
+	case reflect.Slice, reflect.Array:
+		return e.index.Extract(ctx, v)
+	case reflect.Map:
+		if kt := x.Type().Key(); kt.Kind() != reflect.Interface && isIndexableMapKey(kt) {
+			return e.index.Extract(ctx, v)
+		}
+	}
+	return e.key.Extract(ctx, v)
+}
+
+// String returns e as string. It is the index form, which ParseString does not
+// parse back into e; see ParseJSONPointer.
+func (e *pointerToken) String() string {
+	return e.index.String()
+}
@@ -0,0 +1,197 @@
+package query
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseJSONPointer(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			src          string
+			target       any
+			expect       any
+			expectString string
+		}{
+			"empty": {
+				src:          "",
+				target:       "whole",
+				expect:       "whole",
+				expectString: "",
+			},
+			"index of sequence": {
+				src: "/items/0/name",
+				target: map[string]any{
+					"items": []any{
+						map[string]any{"name": "a"},
+					},
+				},
+				expect:       "a",
+				expectString: ".items[0].name",
+			},
+			"key of map": {
+				src: "/m/0",
+				target: map[string]any{
+					"m": map[string]any{"0": "zero"},
+				},
+				expect:       "zero",
+				expectString: ".m[0]",
+			},
+			"integer key of map": {
+				src:          "/0",
+				target:       map[int]string{0: "int"},
+				expect:       "int",
+				expectString: "[0]",
+			},
+			"escaped": {
+				src: "/a~1b/c~0d",
+				target: map[string]any{
+					"a/b": map[string]any{"c~d": "escaped"},
+				},
+				expect:       "escaped",
+				expectString: ".a/b.c~d",
+			},
+			"leading zero is a key": {
+				src:          "/01",
+				target:       map[string]string{"01": "key"},
+				expect:       "key",
+				expectString: ".01",
+			},
+			"empty key": {
+				src:          "/",
+				target:       map[string]string{"": "empty"},
+				expect:       "empty",
+				expectString: "['']",
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				q, err := ParseJSONPointer(test.src)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got := q.String(); got != test.expectString {
+					t.Errorf("expect %q but got %q", test.expectString, got)
+				}
+				got, err := q.Extract(context.Background(), test.target)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if diff := cmp.Diff(test.expect, got); diff != "" {
+					t.Errorf("differs: (-want +got)\n%s", diff)
+				}
+				p, err := q.JSONPointer()
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if p != test.src {
+					t.Errorf("expect %q but got %q", test.src, p)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			src string
+		}{
+			"no leading slash": {
+				src: "a/b",
+			},
+			"invalid escape": {
+				src: "/a~2",
+			},
+			"trailing tilde": {
+				src: "/a~",
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				if _, err := ParseJSONPointer(test.src); err == nil {
+					t.Fatal("no error")
+				}
+			})
+		}
+	})
+	t.Run("round-trip", func(t *testing.T) {
+		target := map[string]any{"m": map[string]any{"0": "zero"}}
+		q, err := ParseJSONPointer("/m/0")
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if _, err := q.MarshalText(); err == nil {
+			t.Fatal("MarshalText: no error")
+		}
+		if _, err := q.Format(FormatStyle{}); err == nil {
+			t.Fatal("Format: no error")
+		}
+		p, err := q.JSONPointer()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		reparsed, err := ParseJSONPointer(p)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		got, err := reparsed.Extract(context.Background(), target)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != "zero" {
+			t.Errorf("expect %q but got %v", "zero", got)
+		}
+	})
+}
+
+func TestQuery_JSONPointer(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *Query
+			expect string
+		}{
+			"empty": {
+				query:  New(),
+				expect: "",
+			},
+			"root": {
+				query:  New().Root(),
+				expect: "",
+			},
+			"keys and indexes": {
+				query:  New().Root().Key("a/b").Index(0).Key("~"),
+				expect: "/a~1b/0/~0",
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				got, err := test.query.JSONPointer()
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expect {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query *Query
+		}{
+			"negative index": {
+				query: New().Key("a").Index(-1),
+			},
+			"custom extractor": {
+				query: New().Append(extractorFunc(nil)),
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				if _, err := test.query.JSONPointer(); err == nil {
+					t.Fatal("no error")
+				}
+			})
+		}
+	})
+}
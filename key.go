@@ -5,6 +5,8 @@ import (
 	"errors"
 	"reflect"
 	"strings"
+
+	"github.com/zoncoen/query-go/v2/ast"
 )
 
 // KeyExtractor is the interface that wraps the ExtractByKey method.
@@ -24,12 +26,14 @@ type Key struct {
 	customExtractFuncs []func(ExtractFunc) ExtractFunc
 	fieldNameGetter    func(f reflect.StructField) string
 	isInlineFuncs      []func(reflect.StructField) bool
+	trace              bool
 }
 
 // Extract extracts the value from v by key, passing ctx (extended with the
 // query options) to v.ExtractByKey if v implements the KeyExtractor
 // interface. It returns ErrNotFound (possibly wrapped) when the key is
-// absent.
+// absent, and a *TypeMismatchError when v is neither a map with string keys
+// nor a struct.
 func (e *Key) Extract(ctx context.Context, v reflect.Value) (reflect.Value, error) {
 	// CanInterface is required: values obtained from unexported fields are
 	// read-only and Interface would panic on them.
@@ -46,6 +50,7 @@ func (e *Key) Extract(ctx context.Context, v reflect.Value) (reflect.Value, erro
 			if err != nil {
 				return reflect.Value{}, err
 			}
+			e.traceResolution(ctx, ResolutionKeyExtractor)
 			return reflect.ValueOf(x), nil
 		}
 	}
@@ -55,11 +60,17 @@ func (e *Key) Extract(ctx context.Context, v reflect.Value) (reflect.Value, erro
 func (e *Key) extract(ctx context.Context, v reflect.Value) (reflect.Value, error) {
 	v = elem(v)
 	switch v.Kind() {
+	case reflect.Invalid:
 	case reflect.Map:
-		if kt := v.Type().Key(); kt.Kind() == reflect.String {
+		kt := v.Type().Key()
+		if kt.Kind() != reflect.String && kt.Kind() != reflect.Interface {
+			return reflect.Value{}, typeMismatch(v, reflect.Map, reflect.Struct)
+		}
+		if kt.Kind() == reflect.String {
 			// Fast path: an exact match is a map lookup, not a linear scan.
 			// It also takes precedence over case-insensitive matches.
 			if x := v.MapIndex(reflect.ValueOf(e.key).Convert(kt)); x.IsValid() {
+				e.traceResolution(ctx, ResolutionMapKey)
 				return x, nil
 			}
 			if !e.caseInsensitive {
@@ -80,6 +91,7 @@ func (e *Key) extract(ctx context.Context, v reflect.Value) (reflect.Value, erro
 			}
 			ks := k.String()
 			if ks == e.key {
+				e.traceResolution(ctx, ResolutionMapKey)
 				return v.MapIndex(k), nil
 			}
 			if !e.caseInsensitive {
@@ -93,11 +105,13 @@ func (e *Key) extract(ctx context.Context, v reflect.Value) (reflect.Value, erro
 			}
 		}
 		if found.IsValid() {
+			e.traceResolution(ctx, ResolutionMapKey)
 			return found, nil
 		}
 	case reflect.Struct:
 		inlines := []int{}
 		var unexported *reflect.Value
+		var unexportedResolution Resolution
 		for i := range v.Type().NumField() {
 			field := v.Type().FieldByIndex([]int{i})
 			fieldNames := []string{}
@@ -116,17 +130,23 @@ func (e *Key) extract(ctx context.Context, v reflect.Value) (reflect.Value, erro
 					}
 				}
 			}
+			tagNames := len(fieldNames)
 			fieldNames = append(fieldNames, e.getFieldName(field))
-			for _, name := range fieldNames {
+			for j, name := range fieldNames {
 				n, k := name, e.key
 				if e.caseInsensitive {
 					n, k = strings.ToLower(n), strings.ToLower(k)
 				}
 				if n == k {
+					res := ResolutionStructField
+					if j < tagNames {
+						res = ResolutionStructTag
+					}
 					val := v.FieldByIndex([]int{i})
 					if isUnexportedField(val) {
-						unexported = &val
+						unexported, unexportedResolution = &val, res
 					} else {
+						e.traceResolution(ctx, res)
 						return val, nil
 					}
 				}
@@ -153,8 +173,9 @@ func (e *Key) extract(ctx context.Context, v reflect.Value) (reflect.Value, erro
 				val, err := f(ctx, v.FieldByIndex([]int{i}))
 				if err == nil {
 					if isUnexportedField(val) {
-						unexported = &val
+						unexported, unexportedResolution = &val, ResolutionInlineField
 					} else {
+						e.traceResolution(ctx, ResolutionInlineField)
 						return val, nil
 					}
 				} else if !errors.Is(err, ErrNotFound) {
@@ -165,12 +186,20 @@ func (e *Key) extract(ctx context.Context, v reflect.Value) (reflect.Value, erro
 			}
 		}
 		if unexported != nil {
+			e.traceResolution(ctx, unexportedResolution)
 			return *unexported, nil
 		}
+	default:
+		return reflect.Value{}, typeMismatch(v, reflect.Map, reflect.Struct)
 	}
 	return reflect.Value{}, ErrNotFound
 }
 
+// Key returns the key of e.
+func (e *Key) Key() string {
+	return e.key
+}
+
 func (e *Key) getFieldName(field reflect.StructField) string {
 	if e.fieldNameGetter != nil {
 		return e.fieldNameGetter(field)
@@ -190,30 +219,5 @@ func isUnexportedField(v reflect.Value) bool {
 // selector notation (e.g. an empty key, or a key containing "$" or "]")
 // are rendered in the quoted form.
 func (e *Key) String() string {
-	if e.key == "" {
-		return quote(e.key)
-	}
-	for _, ch := range e.key {
-		switch ch {
-		case '[', ']', '.', '\\', '\'', '$':
-			return quote(e.key)
-		}
-	}
-	return "." + e.key
-}
-
-func quote(s string) string {
-	var b strings.Builder
-	b.WriteString("['")
-	for _, ch := range s {
-		switch ch {
-		case '\\', '\'':
-			b.WriteRune('\\')
-			fallthrough
-		default:
-			b.WriteRune(ch)
-		}
-	}
-	b.WriteString("']")
-	return b.String()
+	return ast.Sprint(&ast.Selector{Sel: e.key})
 }
@@ -378,6 +378,86 @@ func TestKey_Extract(t *testing.T) {
 	})
 }
 
+func TestKey_Extract_TypeMismatch(t *testing.T) {
+	tests := map[string]struct {
+		v        any
+		mismatch bool
+	}{
+		"int": {
+			v:        1,
+			mismatch: true,
+		},
+		"pointer to string": {
+			v:        new(string),
+			mismatch: true,
+		},
+		"integer-keyed map": {
+			v:        map[int]string{},
+			mismatch: true,
+		},
+		"nil": {
+			v: nil,
+		},
+		"nil pointer to struct": {
+			v: (*testTags)(nil),
+		},
+		"interface-keyed map": {
+			v: map[any]string{},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			e := &Key{key: "name"}
+			_, err := e.Extract(context.Background(), reflect.ValueOf(test.v))
+			if !errors.Is(err, ErrNotFound) {
+				t.Fatalf("expected ErrNotFound but got: %v", err)
+			}
+			if got := errors.Is(err, ErrTypeMismatch); got != test.mismatch {
+				t.Fatalf("expected errors.Is(err, ErrTypeMismatch) to be %t but got %t", test.mismatch, got)
+			}
+			if test.mismatch {
+				var tme *TypeMismatchError
+				if !errors.As(err, &tme) {
+					t.Fatalf("expected *TypeMismatchError but got %T", err)
+				}
+				if diff := cmp.Diff([]reflect.Kind{reflect.Map, reflect.Struct}, tme.Expected); diff != "" {
+					t.Errorf("differs: (-want +got)\n%s", diff)
+				}
+				if got, expect := tme.Type, reflect.TypeOf(test.v); got != expect {
+					t.Errorf("expected %s but got %s", expect, got)
+				}
+			}
+		})
+	}
+}
+
+func TestKey_Key(t *testing.T) {
+	tests := map[string]struct {
+		key string
+	}{
+		"simple": {
+			key: "aaa",
+		},
+		"quoted": {
+			key: "a.b",
+		},
+		"empty": {
+			key: "",
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			k, ok := New().Key(test.key).Extractors()[0].(*Key)
+			if !ok {
+				t.Fatal("expected *Key")
+			}
+			if got := k.Key(); got != test.key {
+				t.Errorf("expect %q but got %q", test.key, got)
+			}
+		})
+	}
+}
+
 func TestKey_String(t *testing.T) {
 	tests := map[string]struct {
 		key    string
@@ -0,0 +1,74 @@
+package query
+
+import (
+	"flag"
+	"fmt"
+)
+
+// MarshalText implements the encoding.TextMarshaler interface. It returns the
+// string representation of q, or empty text if q is nil. It returns an error
+// if q has an extractor other than Key and Index, since ParseString does not
+// parse its string representation back into the same extractor.
+func (q *Query) MarshalText() ([]byte, error) {
+	if q == nil {
+		return []byte{}, nil
+	}
+	for i, e := range q.extractors {
+		switch e.(type) {
+		case *Key, *Index:
+		default:
+			return nil, fmt.Errorf("can not marshal %q as text: unsupported extractor %T at %s", q.String(), e, q.prefixString(i+1))
+		}
+	}
+	return []byte(q.String()), nil
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface. It parses
+// text by ParseString with the options of q, so a field initialized with
+// New(opts...) keeps the options while a zero Query has no options:
+//
+//	cfg := Config{Path: query.New(query.CaseInsensitive())}
+//	err := json.Unmarshal(b, &cfg)
+//
+// The parse error is returned wrapped, so errors.As finds parser.Errors which
+// has the position.
+func (q *Query) UnmarshalText(text []byte) error {
+	p, err := ParseString(string(text), q.opts...)
+	if err != nil {
+		return fmt.Errorf("failed to parse query %q: %w", text, err)
+	}
+	*q = *p
+	return nil
+}
+
+// FlagValue returns the flag.Value which parses the flag value into *p by
+// ParseString with opts.
+//
+//	var q *query.Query
+//	flag.Var(query.FlagValue(&q, query.CaseInsensitive()), "query", "query to extract")
+func FlagValue(p **Query, opts ...Option) flag.Value {
+	return &flagValue{p: p, opts: opts}
+}
+
+type flagValue struct {
+	p    **Query
+	opts []Option
+}
+
+// String implements the flag.Value interface.
+func (v *flagValue) String() string {
+	if v.p == nil || *v.p == nil {
+		return ""
+	}
+	return (*v.p).String()
+}
+
+// Set implements the flag.Value interface.
+func (v *flagValue) Set(s string) error {
+	q, err := ParseString(s, v.opts...)
+	if err != nil {
+		return err
+	}
+	*v.p = q
+	return nil
+}
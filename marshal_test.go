@@ -0,0 +1,134 @@
+package query
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"io"
+	"testing"
+
+	"github.com/zoncoen/query-go/v2/parser"
+)
+
+type marshalConfig struct {
+	Path *Query `json:"path"`
+}
+
+func TestQuery_MarshalText(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		b, err := json.Marshal(marshalConfig{Path: New().Root().Key("a.b").Index(0)})
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got, expect := string(b), `{"path":"$['a.b'][0]"}`; got != expect {
+			t.Errorf("expect %s but got %s", expect, got)
+		}
+	})
+	t.Run("nil", func(t *testing.T) {
+		var q *Query
+		b, err := q.MarshalText()
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got := string(b); got != "" {
+			t.Errorf("expect empty text but got %q", got)
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			query  *Query
+			expect string
+		}{
+			"custom extractor": {
+				query:  New().Key("a").Append(extractorFunc(nil)).Key("b"),
+				expect: `can not marshal ".a.b" as text: unsupported extractor query.extractorFunc at .a`,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := test.query.MarshalText()
+				if err == nil {
+					t.Fatal("no error")
+				}
+				if got := err.Error(); got != test.expect {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+				if _, err := json.Marshal(marshalConfig{Path: test.query}); err == nil {
+					t.Fatal("no error")
+				}
+			})
+		}
+	})
+}
+
+func TestQuery_UnmarshalText(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			cfg                   marshalConfig
+			expectCaseInsensitive bool
+		}{
+			"nil": {},
+			"keep options": {
+				cfg:                   marshalConfig{Path: New(CaseInsensitive())},
+				expectCaseInsensitive: true,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				cfg := test.cfg
+				if err := json.Unmarshal([]byte(`{"path":"$.a[0]"}`), &cfg); err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got, expect := cfg.Path.String(), "$.a[0]"; got != expect {
+					t.Errorf("expect %s but got %s", expect, got)
+				}
+				if got := cfg.Path.caseInsensitive; got != test.expectCaseInsensitive {
+					t.Errorf("expect caseInsensitive %t but got %t", test.expectCaseInsensitive, got)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		var cfg marshalConfig
+		err := json.Unmarshal([]byte(`{"path":"$.a[b]"}`), &cfg)
+		var errs parser.Errors
+		if !errors.As(err, &errs) {
+			t.Fatalf("expected parser.Errors but got %v", err)
+		}
+		if got, expect := errs[0].Pos, 5; got != expect {
+			t.Errorf("expect %d but got %d", expect, got)
+		}
+	})
+}
+
+func TestFlagValue(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		var q *Query
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.Var(FlagValue(&q, CaseInsensitive()), "query", "query to extract")
+		if err := fs.Parse([]string{"-query", "$.a[0]"}); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got, expect := q.String(), "$.a[0]"; got != expect {
+			t.Errorf("expect %s but got %s", expect, got)
+		}
+		if !q.caseInsensitive {
+			t.Error("the options are not applied")
+		}
+		if got, expect := fs.Lookup("query").Value.String(), "$.a[0]"; got != expect {
+			t.Errorf("expect %s but got %s", expect, got)
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		var q *Query
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		fs.Var(FlagValue(&q), "query", "query to extract")
+		if err := fs.Parse([]string{"-query", "$.a[b]"}); err == nil {
+			t.Fatal("no error")
+		}
+		if q != nil {
+			t.Errorf("expect nil but got %s", q)
+		}
+	})
+}
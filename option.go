@@ -48,3 +48,46 @@ func CustomIsInlineStructFieldFunc(f func(reflect.StructField) bool) Option {
 		q.customIsInlineFuncs = append(q.customIsInlineFuncs, f)
 	}
 }
+
+// Suggestions returns the Option to report the available keys and the
+// suggestions as NotFoundError.Available and NotFoundError.Suggestions when a
+// key is not found. They are not computed by default, since listing the keys
+// applies the custom extract funcs to the value again.
+func Suggestions() Option {
+	return func(q *Query) {
+		q.suggestions = true
+	}
+}
+
+// SuggestionsInMessage returns the Option to append the suggestions of
+// NotFoundError to the error message, e.g. `"$.user.emial" not found; did you
+// mean "email"?`. It implies the Suggestions option.
+func SuggestionsInMessage() Option {
+	return func(q *Query) {
+		q.suggestions = true
+		q.suggestionsInMessage = true
+	}
+}
+
+// RecoverPanic returns the Option to recover a panic raised during an
+// extraction step, e.g. by a KeyExtractor, an IndexExtractor or a
+// CustomExtractFunc. Query.Extract returns the panic as an *ExtractError
+// wrapping a *PanicError instead of crashing.
+func RecoverPanic() Option {
+	return func(q *Query) {
+		q.recoverPanic = true
+	}
+}
+
+// WithTracer returns the Option to call the StepStart and StepEnd methods of t
+// for each step of Query.Extract, e.g. to print an extraction trace in
+// verbose test output. The steps are not traced by default.
+//
+// StepResult.Resolution is recorded only by the Key and Index created by the
+// query with this option, e.g. by Query.Key or ParseString, so that the
+// extraction without a tracer does no extra work.
+func WithTracer(t Tracer) Option {
+	return func(q *Query) {
+		q.tracer = t
+	}
+}
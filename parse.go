@@ -15,7 +15,7 @@ func Parse(r io.Reader, opts ...Option) (*Query, error) {
 	if err != nil {
 		return nil, err
 	}
-	return buildQuery(New(opts...), node)
+	return FromAST(node, opts...)
 }
 
 // ParseString parses a query string s and returns the corresponding Query.
@@ -23,6 +23,12 @@ func ParseString(s string, opts ...Option) (*Query, error) {
 	return Parse(strings.NewReader(s), opts...)
 }
 
+// FromAST returns the Query corresponding to node, e.g. a node built by hand
+// or rewritten after Parse. A nil node results in an empty Query.
+func FromAST(node ast.Node, opts ...Option) (*Query, error) {
+	return buildQuery(New(opts...), node)
+}
+
 func buildQuery(q *Query, node ast.Node) (*Query, error) {
 	if q == nil || node == nil {
 		return q, nil
@@ -4,6 +4,8 @@ import (
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
+
+	"github.com/zoncoen/query-go/v2/ast"
 )
 
 func TestParseString(t *testing.T) {
@@ -43,3 +45,38 @@ func TestParseString(t *testing.T) {
 		}
 	})
 }
+
+func TestFromAST(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		node := &ast.Index{
+			X: &ast.Selector{
+				X:   &ast.Root{},
+				Sel: "a.b",
+			},
+			Index: 1,
+		}
+		got, err := FromAST(node, CaseInsensitive())
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		expected := New(CaseInsensitive()).Root().Key("a.b").Index(1)
+		if diff := cmp.Diff(expected.String(), got.String()); diff != "" {
+			t.Errorf("differs: (-want +got)\n%s", diff)
+		}
+		if s := ast.Sprint(node); s != got.String() {
+			t.Errorf("expected %q but got %q", got.String(), s)
+		}
+		if !got.caseInsensitive {
+			t.Error("the options are not applied")
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		if _, err := FromAST(&ast.Selector{X: unknownNode{}, Sel: "a"}); err == nil {
+			t.Fatal("no error")
+		}
+	})
+}
+
+type unknownNode struct{}
+
+func (unknownNode) Pos() int { return 0 }
@@ -1,20 +1,84 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zoncoen/query-go/v2/token"
+)
+
+// ErrorCode represents the kind of a parse error.
+type ErrorCode int
+
+const (
+	// CodeUnknown is the code of the errors appended by Errors.Append.
+	CodeUnknown ErrorCode = iota
+	// CodeUnexpectedToken reports a token which is not allowed at the
+	// position. Error.Expected has the allowed tokens.
+	CodeUnexpectedToken
+	// CodeUnterminatedString reports a quoted string without the closing
+	// quote.
+	CodeUnterminatedString
+	// CodeInvalidEscape reports a backslash which escapes neither a
+	// backslash nor the quote.
+	CodeInvalidEscape
+	// CodeLeadingZero reports an index with a leading zero like "[01]".
+	CodeLeadingZero
+	// CodeInvalidInteger reports an index which is not a valid integer,
+	// such as "-", "-0" or an integer out of range.
+	CodeInvalidInteger
+	// CodeIllegalCharacter reports a character which is not allowed in
+	// brackets.
+	CodeIllegalCharacter
+)
+
+// Sentinel errors to test the code of a parse error by errors.Is. Errors
+// matches them if any of the errors has the code.
+var (
+	ErrUnexpectedToken    = errors.New("unexpected token")
+	ErrUnterminatedString = errors.New("unterminated string")
+	ErrInvalidEscape      = errors.New("invalid escape sequence")
+	ErrLeadingZero        = errors.New("leading zero in index")
+	ErrInvalidInteger     = errors.New("invalid integer")
+	ErrIllegalCharacter   = errors.New("illegal character")
 )
 
+var codeToSentinelErrors = map[ErrorCode]error{
+	CodeUnexpectedToken:    ErrUnexpectedToken,
+	CodeUnterminatedString: ErrUnterminatedString,
+	CodeInvalidEscape:      ErrInvalidEscape,
+	CodeLeadingZero:        ErrLeadingZero,
+	CodeInvalidInteger:     ErrInvalidInteger,
+	CodeIllegalCharacter:   ErrIllegalCharacter,
+}
+
+// String returns c as string.
+func (c ErrorCode) String() string {
+	if err, ok := codeToSentinelErrors[c]; ok {
+		return err.Error()
+	}
+	return "unknown"
+}
+
 // Errors represents parse errors.
 type Errors []*Error
 
-// Append appends a parse error to errs.
+// Append appends a parse error to errs. The error has CodeUnknown and spans
+// the single column at pos.
 func (errs *Errors) Append(pos int, msg string) {
 	*errs = append(*errs, &Error{
-		pos: pos,
-		msg: msg,
+		Pos: pos,
+		End: pos + 1,
+		Msg: msg,
 	})
 }
 
+func (errs *Errors) append(err *Error) {
+	*errs = append(*errs, err)
+}
+
 // Error returns error string.
 func (errs Errors) Error() string {
 	switch len(errs) {
@@ -35,13 +99,64 @@ func (errs Errors) Err() error {
 	return errs
 }
 
+// Unwrap returns the errors, so that errors.Is and errors.As examine all of
+// them.
+func (errs Errors) Unwrap() []error {
+	es := make([]error, len(errs))
+	for i, err := range errs {
+		es[i] = err
+	}
+	return es
+}
+
+// Format renders all errors with src, the parsed query, by Error.Format.
+func (errs Errors) Format(src string) string {
+	s := make([]string, len(errs))
+	for i, err := range errs {
+		s[i] = err.Format(src)
+	}
+	return strings.Join(s, "\n")
+}
+
 // Error represents a parse error.
 type Error struct {
-	pos int
-	msg string
+	// Pos is the column where the error is found. The first character of
+	// the query is at column 1.
+	Pos int
+	// End is the column just after the offending span.
+	End int
+	// Code is the kind of the error.
+	Code ErrorCode
+	// Expected is the tokens which are allowed at Pos if Code is
+	// CodeUnexpectedToken.
+	Expected []token.Token
+	// Msg is the error message.
+	Msg string
 }
 
 // Error returns error string.
 func (e *Error) Error() string {
-	return fmt.Sprintf("col %d: %s", e.pos, e.msg)
+	return fmt.Sprintf("col %d: %s", e.Pos, e.Msg)
+}
+
+// Is reports whether target is the sentinel error of the code of e.
+func (e *Error) Is(target error) bool {
+	err, ok := codeToSentinelErrors[e.Code]
+	return ok && err == target
+}
+
+// Format renders e with src, the parsed query, marking the offending span
+// with "^~~~" like the following.
+//
+//	col 4: unterminated string
+//	["0
+//	   ^
+func (e *Error) Format(src string) string {
+	pos := max(e.Pos, 1)
+	width := max(e.End-pos, 1)
+	// The span may end at EOF, which is just after the last character.
+	if n := utf8.RuneCountInString(src) + 1; pos+width > n+1 {
+		width = max(n+1-pos, 1)
+	}
+	return fmt.Sprintf("%s\n%s\n%s^%s", e.Error(), src, strings.Repeat(" ", pos-1), strings.Repeat("~", width-1))
 }
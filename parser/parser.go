@@ -15,8 +15,10 @@ import (
 type Parser struct {
 	s      *scanner
 	pos    int
+	end    int
 	tok    token.Token
 	lit    string
+	err    *Error
 	errors Errors
 }
 
@@ -32,6 +34,10 @@ func (p *Parser) Parse() (ast.Node, error) {
 
 func (p *Parser) next() {
 	p.pos, p.tok, p.lit = p.s.scan()
+	p.end, p.err = p.s.pos, p.s.err
+	if p.tok == token.EOF {
+		p.end = p.pos + 1
+	}
 }
 
 func (p *Parser) parse() ast.Node {
@@ -47,6 +53,7 @@ L:
 			p.next()
 			node = &ast.Selector{
 				ValuePos: pos,
+				ValueEnd: p.end,
 				X:        node,
 				Sel:      p.lit,
 			}
@@ -74,6 +81,7 @@ func (p *Parser) parseFirst() ast.Node {
 	case token.STRING:
 		node = &ast.Selector{
 			ValuePos: p.pos,
+			ValueEnd: p.end,
 			Sel:      p.lit,
 		}
 		p.next()
@@ -82,6 +90,7 @@ func (p *Parser) parseFirst() ast.Node {
 		p.next()
 		node = &ast.Selector{
 			ValuePos: pos,
+			ValueEnd: p.end,
 			X:        node,
 			Sel:      p.lit,
 		}
@@ -117,25 +126,33 @@ func (p *Parser) parseIndex(x ast.Node) ast.Node {
 	default:
 		p.expect(token.STRING, token.INT)
 	}
+	end := p.end
 	p.expect(token.RBRACK)
+	switch n := node.(type) {
+	case *ast.Selector:
+		n.ValueEnd = end
+	case *ast.Index:
+		n.ValueEnd = end
+	}
 	return node
 }
 
 func (p *Parser) parseInt() int {
-	pos, lit := p.pos, p.lit
+	pos, end, lit := p.pos, p.end, p.lit
 	p.next()
 	i, err := strconv.Atoi(lit)
 	if err != nil {
-		p.error(pos, fmt.Sprintf("%s is not an integer", lit))
+		p.errors.append(&Error{
+			Pos:  pos,
+			End:  end,
+			Code: CodeInvalidInteger,
+			Msg:  fmt.Sprintf("%s is not an integer", lit),
+		})
 		return 0
 	}
 	return i
 }
 
-func (p *Parser) error(pos int, msg string) {
-	p.errors.Append(pos, msg)
-}
-
 func (p *Parser) expect(toks ...token.Token) {
 	var ok bool
 	strs := make([]string, len(toks))
@@ -147,7 +164,18 @@ func (p *Parser) expect(toks ...token.Token) {
 		strs[i] = fmt.Sprintf(`"%s"`, tok)
 	}
 	if !ok {
-		p.error(p.pos, fmt.Sprintf(`expected %s but found "%s"`, strings.Join(strs, " or "), p.tok))
+		if p.tok == token.ILLEGAL && p.err != nil {
+			// Report the reason why the scanner could not read a token.
+			p.errors.append(p.err)
+		} else {
+			p.errors.append(&Error{
+				Pos:      p.pos,
+				End:      p.end,
+				Code:     CodeUnexpectedToken,
+				Expected: toks,
+				Msg:      fmt.Sprintf(`expected %s but found "%s"`, strings.Join(strs, " or "), p.tok),
+			})
+		}
 	}
 	p.next() // make progress
 }
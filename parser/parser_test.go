@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"errors"
 	"strings"
 	"testing"
 
@@ -28,6 +29,7 @@ func TestParser_Parse(t *testing.T) {
 				src: "$.selector",
 				expected: &ast.Selector{
 					ValuePos: 2,
+					ValueEnd: 11,
 					X: &ast.Root{
 						ValuePos: 1,
 					},
@@ -38,6 +40,7 @@ func TestParser_Parse(t *testing.T) {
 				src: ".selector",
 				expected: &ast.Selector{
 					ValuePos: 1,
+					ValueEnd: 10,
 					Sel:      "selector",
 				},
 			},
@@ -45,6 +48,7 @@ func TestParser_Parse(t *testing.T) {
 				src: "selector",
 				expected: &ast.Selector{
 					ValuePos: 1,
+					ValueEnd: 9,
 					Sel:      "selector",
 				},
 			},
@@ -52,6 +56,7 @@ func TestParser_Parse(t *testing.T) {
 				src: "$[0]",
 				expected: &ast.Index{
 					ValuePos: 2,
+					ValueEnd: 5,
 					X: &ast.Root{
 						ValuePos: 1,
 					},
@@ -62,6 +67,7 @@ func TestParser_Parse(t *testing.T) {
 				src: "$[-1]",
 				expected: &ast.Index{
 					ValuePos: 2,
+					ValueEnd: 6,
 					X: &ast.Root{
 						ValuePos: 1,
 					},
@@ -72,6 +78,7 @@ func TestParser_Parse(t *testing.T) {
 				src: "[0]",
 				expected: &ast.Index{
 					ValuePos: 1,
+					ValueEnd: 4,
 					Index:    0,
 				},
 			},
@@ -79,10 +86,13 @@ func TestParser_Parse(t *testing.T) {
 				src: "a.b.c",
 				expected: &ast.Selector{
 					ValuePos: 4,
+					ValueEnd: 6,
 					X: &ast.Selector{
 						ValuePos: 2,
+						ValueEnd: 4,
 						X: &ast.Selector{
 							ValuePos: 1,
+							ValueEnd: 2,
 							Sel:      "a",
 						},
 						Sel: "b",
@@ -94,10 +104,13 @@ func TestParser_Parse(t *testing.T) {
 				src: `["0"]["1"]["2"]`,
 				expected: &ast.Selector{
 					ValuePos: 11,
+					ValueEnd: 16,
 					X: &ast.Selector{
 						ValuePos: 6,
+						ValueEnd: 11,
 						X: &ast.Selector{
 							ValuePos: 1,
+							ValueEnd: 6,
 							Sel:      "0",
 						},
 						Sel: "1",
@@ -109,10 +122,13 @@ func TestParser_Parse(t *testing.T) {
 				src: "[0][1][2]",
 				expected: &ast.Index{
 					ValuePos: 7,
+					ValueEnd: 10,
 					X: &ast.Index{
 						ValuePos: 4,
+						ValueEnd: 7,
 						X: &ast.Index{
 							ValuePos: 1,
+							ValueEnd: 4,
 							Index:    0,
 						},
 						Index: 1,
@@ -136,20 +152,67 @@ func TestParser_Parse(t *testing.T) {
 	})
 	t.Run("failure", func(t *testing.T) {
 		tests := map[string]struct {
-			src string
-			pos int
+			src      string
+			pos      int
+			end      int
+			code     ErrorCode
+			sentinel error
 		}{
 			"expected ] but got EOF": {
-				src: "[0",
-				pos: 3,
+				src:      "[0",
+				pos:      3,
+				end:      4,
+				code:     CodeUnexpectedToken,
+				sentinel: ErrUnexpectedToken,
 			},
 			"expected ] but got [": {
-				src: "[0[1]",
-				pos: 3,
+				src:      "[0[1]",
+				pos:      3,
+				end:      4,
+				code:     CodeIllegalCharacter,
+				sentinel: ErrIllegalCharacter,
 			},
 			`expected " but got EOF`: {
-				src: `["0`,
-				pos: 4,
+				src:      `["0`,
+				pos:      4,
+				end:      5,
+				code:     CodeUnterminatedString,
+				sentinel: ErrUnterminatedString,
+			},
+			"invalid escape": {
+				src:      `['a\b']`,
+				pos:      4,
+				end:      6,
+				code:     CodeInvalidEscape,
+				sentinel: ErrInvalidEscape,
+			},
+			"leading zero": {
+				src:      "$[007]",
+				pos:      3,
+				end:      6,
+				code:     CodeLeadingZero,
+				sentinel: ErrLeadingZero,
+			},
+			"negative zero": {
+				src:      "$[-0]",
+				pos:      3,
+				end:      5,
+				code:     CodeInvalidInteger,
+				sentinel: ErrInvalidInteger,
+			},
+			"integer out of range": {
+				src:      "$[99999999999999999999]",
+				pos:      3,
+				end:      23,
+				code:     CodeInvalidInteger,
+				sentinel: ErrInvalidInteger,
+			},
+			"illegal character": {
+				src:      "$[a]",
+				pos:      3,
+				end:      4,
+				code:     CodeIllegalCharacter,
+				sentinel: ErrIllegalCharacter,
 			},
 		}
 		for name, test := range tests {
@@ -163,10 +226,54 @@ func TestParser_Parse(t *testing.T) {
 				if !ok {
 					t.Fatalf("expected parse errors: %s", err)
 				}
-				if got, expected := errs[0].pos, test.pos; got != expected {
+				if got, expected := errs[0].Pos, test.pos; got != expected {
 					t.Fatalf("expected %d but got %d: %s", expected, got, err)
 				}
+				if got, expected := errs[0].End, test.end; got != expected {
+					t.Errorf("expected end %d but got %d: %s", expected, got, err)
+				}
+				if got, expected := errs[0].Code, test.code; got != expected {
+					t.Errorf("expected code %s but got %s: %s", expected, got, err)
+				}
+				if !errors.Is(err, test.sentinel) {
+					t.Errorf("expected %v but got %s", test.sentinel, err)
+				}
 			})
 		}
 	})
 }
+
+func TestErrors_Format(t *testing.T) {
+	src := "$.a[007].b[x"
+	_, err := NewParser(strings.NewReader(src)).Parse()
+	var errs Errors
+	if !errors.As(err, &errs) {
+		t.Fatalf("expected parse errors: %s", err)
+	}
+	expected := `col 5: leading zero in index 007
+$.a[007].b[x
+    ^~~
+col 12: illegal character 'x' in brackets
+$.a[007].b[x
+           ^
+col 13: expected "rbrack" but found "EOF"
+$.a[007].b[x
+            ^`
+	if diff := cmp.Diff(expected, errs.Format(src)); diff != "" {
+		t.Errorf("result differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestErrors_Append(t *testing.T) {
+	var errs Errors
+	errs.Append(2, "custom error")
+	if got, expected := errs.Error(), "col 2: custom error"; got != expected {
+		t.Errorf("expected %q but got %q", expected, got)
+	}
+	if got, expected := errs[0].Format("abc"), "col 2: custom error\nabc\n ^"; got != expected {
+		t.Errorf("expected %q but got %q", expected, got)
+	}
+	if errors.Is(errs.Err(), ErrUnexpectedToken) {
+		t.Error("unexpected code")
+	}
+}
@@ -2,6 +2,7 @@ package parser
 
 import (
 	"bufio"
+	"fmt"
 	"io"
 	"strings"
 	"unicode"
@@ -18,6 +19,8 @@ type scanner struct {
 	pos            int
 	buf            []rune
 	isReadingIndex bool
+	// err is the reason of the last token if it is token.ILLEGAL.
+	err *Error
 }
 
 func newScanner(r io.Reader) *scanner {
@@ -48,6 +51,7 @@ func (s *scanner) unread(ch rune) {
 }
 
 func (s *scanner) scan() (int, token.Token, string) {
+	s.err = nil
 	ch := s.read()
 	if ch == eof {
 		return s.pos, token.EOF, ""
@@ -65,7 +69,7 @@ func (s *scanner) scan() (int, token.Token, string) {
 		if ch == '-' || isDigit(ch) {
 			return s.scanInt(ch)
 		}
-		return s.pos - 1, token.ILLEGAL, string(ch)
+		return s.illegal(s.pos-1, s.pos, CodeIllegalCharacter, string(ch), fmt.Sprintf("illegal character %q in brackets", ch))
 	}
 	switch ch {
 	case '$':
@@ -109,15 +113,17 @@ scan:
 		switch ch {
 		case eof:
 			// string not terminated
-			return s.pos, token.ILLEGAL, ""
+			return s.illegal(s.pos, s.pos+1, CodeUnterminatedString, "", "unterminated string")
 		case '\\':
 			escaped := s.read()
 			switch escaped {
 			case '\\', term:
 				b.WriteRune(escaped)
 				backslashes++
+			case eof:
+				return s.illegal(s.pos, s.pos+1, CodeUnterminatedString, "", "unterminated string")
 			default:
-				return s.pos - 2, token.ILLEGAL, ""
+				return s.illegal(s.pos-2, s.pos, CodeInvalidEscape, "", fmt.Sprintf(`invalid escape sequence "\\%c"`, escaped))
 			}
 		case term:
 			break scan
@@ -142,16 +148,27 @@ scan:
 	}
 	lit := b.String()
 	if head == '0' && b.Len() != 1 {
-		return s.pos - b.Len(), token.ILLEGAL, lit
+		return s.illegal(s.pos-b.Len(), s.pos, CodeLeadingZero, lit, fmt.Sprintf("leading zero in index %s", lit))
 	}
 	// A bare "-" and negative zero ("-0", "-00", ...) are not valid indices;
 	// RFC 9535 (JSONPath) disallows -0 as well.
 	if head == '-' && (b.Len() == 1 || lit[1] == '0') {
-		return s.pos - b.Len(), token.ILLEGAL, lit
+		return s.illegal(s.pos-b.Len(), s.pos, CodeInvalidInteger, lit, fmt.Sprintf("%s is not a valid index", lit))
 	}
 	return s.pos - b.Len(), token.INT, lit
 }
 
+// illegal returns token.ILLEGAL at pos, keeping the reason to report.
+func (s *scanner) illegal(pos, end int, code ErrorCode, lit, msg string) (int, token.Token, string) {
+	s.err = &Error{
+		Pos:  pos,
+		End:  end,
+		Code: code,
+		Msg:  msg,
+	}
+	return pos, token.ILLEGAL, lit
+}
+
 func isDigit(ch rune) bool {
 	return '0' <= ch && ch <= '9' || ch >= utf8.RuneSelf && unicode.IsDigit(ch)
 }
@@ -3,10 +3,11 @@ package query
 import (
 	"context"
 	"errors"
-	"fmt"
 	"reflect"
+	"runtime/debug"
 	"slices"
 	"strings"
+	"time"
 )
 
 // Query represents a query to extract the element from a value.
@@ -18,6 +19,10 @@ type Query struct {
 	customExtractFuncs          []func(ExtractFunc) ExtractFunc
 	customStructFieldNameGetter func(f reflect.StructField) string
 	customIsInlineFuncs         []func(reflect.StructField) bool
+	suggestions                 bool
+	suggestionsInMessage        bool
+	recoverPanic                bool
+	tracer                      Tracer
 	hasExplicitRoot             bool
 }
 
@@ -50,14 +55,25 @@ func (q Query) Root() *Query {
 
 // Key is shorthand method to create Key and appends it.
 func (q Query) Key(k string) *Query {
-	return q.Append(&Key{
+	return q.Append(q.newKey(k))
+}
+
+// newKey returns Key with the options of q.
+func (q *Query) newKey(k string) *Key {
+	return &Key{
 		key:                k,
 		caseInsensitive:    q.caseInsensitive,
 		structTags:         q.structTags,
 		customExtractFuncs: q.customExtractFuncs,
 		fieldNameGetter:    q.customStructFieldNameGetter,
 		isInlineFuncs:      q.customIsInlineFuncs,
-	})
+		trace:              q.tracer != nil,
+	}
+}
+
+// newIndex returns Index with the options of q.
+func (q *Query) newIndex(i int) *Index {
+	return &Index{index: i, trace: q.tracer != nil}
 }
 
 // Index is shorthand method to create Index and appends it.
@@ -65,7 +81,7 @@ func (q Query) Key(k string) *Query {
 // (-1 is the last element); for integer-keyed maps, i is the literal map key.
 // See Index for the exact semantics.
 func (q Query) Index(i int) *Query {
-	return q.Append(&Index{index: i})
+	return q.Append(q.newIndex(i))
 }
 
 // Extract extracts the value by q from target, passing ctx to each
@@ -74,8 +90,10 @@ func (q Query) Index(i int) *Query {
 // When the queried element is absent, the returned error is a *NotFoundError
 // matching ErrNotFound via errors.Is. Any other error reported by an
 // extractor — e.g. a context cancellation that interrupted a blocking
-// extractor — aborts the extraction and is returned wrapped with the
-// position of the failing extractor.
+// extractor — aborts the extraction and is returned as an *ExtractError which
+// has the position of the failing extractor. A panic raised by an extractor
+// is recovered into an *ExtractError wrapping a *PanicError if q has the
+// RecoverPanic option.
 func (q *Query) Extract(ctx context.Context, target any) (any, error) {
 	if q == nil || len(q.extractors) == 0 {
 		return target, nil
@@ -85,20 +103,10 @@ func (q *Query) Extract(ctx context.Context, target any) (any, error) {
 	ctx = withOptions(ctx, q.opts)
 	v := reflect.ValueOf(target)
 	for i, e := range q.extractors {
-		f := e.Extract
-		for j := len(q.customExtractFuncs) - 1; j >= 0; j-- {
-			f = q.customExtractFuncs[j](f)
-		}
 		var err error
-		v, err = f(ctx, v)
+		v, err = q.extract(ctx, i, e, v)
 		if err != nil {
-			if errors.Is(err, ErrNotFound) {
-				return nil, &NotFoundError{Query: q.String(), FailedAt: q.prefixString(i + 1), Err: err}
-			}
-			return nil, fmt.Errorf("%s: %w", q.prefixString(i+1), err)
-		}
-		if v.IsValid() && !v.CanInterface() {
-			return nil, fmt.Errorf("%s: can not access unexported field or method", q.String())
+			return nil, err
 		}
 	}
 	if !v.IsValid() {
@@ -107,6 +115,106 @@ func (q *Query) Extract(ctx context.Context, target any) (any, error) {
 	return v.Interface(), nil
 }
 
+// extract applies the i-th extractor e to in, tracing the step if q has a
+// tracer.
+func (q *Query) extract(ctx context.Context, i int, e Extractor, in reflect.Value) (reflect.Value, error) {
+	if q.tracer == nil {
+		return q.extractStep(ctx, i, e, in)
+	}
+	step := Step{
+		Index:     i,
+		Extractor: e,
+		InputType: valueType(in),
+	}
+	trace := &stepTrace{}
+	ctx = context.WithValue(ctx, stepTraceKey{}, trace)
+	q.tracer.StepStart(ctx, step)
+	start := time.Now()
+	completed := false
+	defer func() {
+		if completed {
+			return
+		}
+		// The step panicked without RecoverPanic: report the panic as the
+		// error of the step and let it continue.
+		if r := recover(); r != nil {
+			q.tracer.StepEnd(ctx, step, StepResult{
+				Resolution: trace.resolution,
+				Duration:   time.Since(start),
+				Err: &PanicError{
+					Value: r,
+					Stack: debug.Stack(),
+				},
+			})
+			panic(r)
+		}
+	}()
+	v, err := q.extractStep(ctx, i, e, in)
+	completed = true
+	res := StepResult{
+		Resolution: trace.resolution,
+		Duration:   time.Since(start),
+		Err:        err,
+	}
+	if err == nil {
+		res.OutputType = valueType(v)
+	}
+	q.tracer.StepEnd(ctx, step, res)
+	return v, err
+}
+
+// extractStep applies the i-th extractor e to in.
+func (q *Query) extractStep(ctx context.Context, i int, e Extractor, in reflect.Value) (v reflect.Value, err error) {
+	if q.recoverPanic {
+		defer func() {
+			if r := recover(); r != nil {
+				v, err = reflect.Value{}, q.extractError(i, in, &PanicError{
+					Value: r,
+					Stack: debug.Stack(),
+				})
+			}
+		}()
+	}
+	f := e.Extract
+	for j := len(q.customExtractFuncs) - 1; j >= 0; j-- {
+		f = q.customExtractFuncs[j](f)
+	}
+	v, err = f(ctx, in)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			nf := &NotFoundError{
+				Query:                q.String(),
+				FailedAt:             q.prefixString(i + 1),
+				Err:                  err,
+				suggestionsInMessage: q.suggestionsInMessage,
+			}
+			if k, ok := e.(*Key); ok && q.suggestions {
+				nf.Available = k.availableKeys(ctx, in)
+				nf.Suggestions = suggest(k.key, nf.Available)
+			}
+			return reflect.Value{}, nf
+		}
+		return reflect.Value{}, q.extractError(i, in, err)
+	}
+	if v.IsValid() && !v.CanInterface() {
+		return reflect.Value{}, q.extractError(i, in, ErrUnexportedField)
+	}
+	return v, nil
+}
+
+// extractError returns the error of the i-th extractor which failed to
+// extract from in.
+func (q *Query) extractError(i int, in reflect.Value, err error) *ExtractError {
+	e := &ExtractError{
+		Query:    q.String(),
+		FailedAt: q.prefixString(i + 1),
+		Step:     i,
+		Err:      err,
+	}
+	e.ValueType = valueType(in)
+	return e
+}
+
 // String returns q as string.
 func (q *Query) String() string {
 	return q.prefixString(len(q.extractors))
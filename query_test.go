@@ -298,6 +298,101 @@ func TestQuery_Extract_FailurePropagation(t *testing.T) {
 	if got, expect := err.Error(), ".messages[0]: context canceled"; got != expect {
 		t.Errorf("expected %q but got %q", expect, got)
 	}
+	var ee *ExtractError
+	if !errors.As(err, &ee) {
+		t.Fatalf("expected *ExtractError but got %T", err)
+	}
+	if ee.Step != 1 {
+		t.Errorf("Step: expected 1 but got %d", ee.Step)
+	}
+	if got, expect := ee.ValueType, reflect.TypeOf(&interruptedExtractor{}); got != expect {
+		t.Errorf("ValueType: expected %s but got %s", expect, got)
+	}
+}
+
+func TestQuery_Extract_UnexportedField(t *testing.T) {
+	type test struct {
+		unexported string //nolint:unused // never read; the test exercises access to an unexported field
+	}
+	q := New().Key("a").Append(extractorFunc(func(_ context.Context, v reflect.Value) (reflect.Value, error) {
+		return reflect.ValueOf(test{}).FieldByName("unexported"), nil
+	}))
+	_, err := q.Extract(context.Background(), map[string]int{"a": 1})
+	if !errors.Is(err, ErrUnexportedField) {
+		t.Fatalf("expected ErrUnexportedField but got: %v", err)
+	}
+	var ee *ExtractError
+	if !errors.As(err, &ee) {
+		t.Fatalf("expected *ExtractError but got %T", err)
+	}
+	if ee.Step != 1 {
+		t.Errorf("Step: expected 1 but got %d", ee.Step)
+	}
+	if got, expect := ee.ValueType, reflect.TypeOf(0); got != expect {
+		t.Errorf("ValueType: expected %s but got %s", expect, got)
+	}
+}
+
+func TestQuery_Extract_RecoverPanic(t *testing.T) {
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			query       *Query
+			expectValue any
+		}{
+			"extractor": {
+				query: New(RecoverPanic()).Key("a").Append(extractorFunc(func(_ context.Context, v reflect.Value) (reflect.Value, error) {
+					panic("broken extractor")
+				})),
+				expectValue: "broken extractor",
+			},
+			"CustomExtractFunc": {
+				query: New(
+					RecoverPanic(),
+					CustomExtractFunc(func(f ExtractFunc) ExtractFunc {
+						return func(ctx context.Context, v reflect.Value) (reflect.Value, error) {
+							if _, ok := v.Interface().(int); ok {
+								var m map[string]int
+								m["a"] = 1
+							}
+							return f(ctx, v)
+						}
+					}),
+				).Key("a").Index(0),
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := test.query.Extract(context.Background(), map[string]any{"a": 1})
+				var ee *ExtractError
+				if !errors.As(err, &ee) {
+					t.Fatalf("expected *ExtractError but got %v", err)
+				}
+				if ee.Step != 1 {
+					t.Errorf("Step: expected 1 but got %d", ee.Step)
+				}
+				var pe *PanicError
+				if !errors.As(err, &pe) {
+					t.Fatalf("expected *PanicError but got %v", err)
+				}
+				if test.expectValue != nil && pe.Value != test.expectValue {
+					t.Errorf("expected %v but got %v", test.expectValue, pe.Value)
+				}
+				if !strings.Contains(string(pe.Stack), "query_test.go") {
+					t.Errorf("the stack does not contain the panicking function:\n%s", pe.Stack)
+				}
+			})
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		defer func() {
+			if r := recover(); r == nil {
+				t.Fatal("expected the panic to propagate without RecoverPanic")
+			}
+		}()
+		_, _ = New().Append(extractorFunc(func(_ context.Context, v reflect.Value) (reflect.Value, error) {
+			panic("broken extractor")
+		})).Extract(context.Background(), 1)
+	})
 }
 
 func TestQuery_Extract_Concurrent(t *testing.T) {
@@ -0,0 +1,143 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"slices"
+	"strings"
+)
+
+// KeyLister is the interface that wraps the Keys method.
+//
+// A KeyExtractor may implement it to report the keys it can extract, which
+// are used as NotFoundError.Available and to compute
+// NotFoundError.Suggestions when a key is not found and the query has the
+// Suggestions option.
+type KeyLister interface {
+	Keys(ctx context.Context) []string
+}
+
+// availableKeys returns the sorted keys which e can extract from v.
+// The custom extract funcs are applied like Extract, so that the values they
+// wrap (e.g. into a KeyExtractor) report their keys as well.
+func (e *Key) availableKeys(ctx context.Context, v reflect.Value) []string {
+	var keys []string
+	f := func(ctx context.Context, v reflect.Value) (reflect.Value, error) {
+		keys = append(keys, e.keys(ctx, v)...)
+		return reflect.Value{}, ErrNotFound
+	}
+	for i := len(e.customExtractFuncs) - 1; i >= 0; i-- {
+		f = e.customExtractFuncs[i](f)
+	}
+	_, _ = f(ctx, v)
+	slices.Sort(keys)
+	return slices.Compact(keys)
+}
+
+func (e *Key) keys(ctx context.Context, v reflect.Value) []string {
+	if v.IsValid() && v.CanInterface() {
+		switch x := v.Interface().(type) {
+		case KeyLister:
+			return x.Keys(ctx)
+		case KeyExtractor:
+			// The fields of a KeyExtractor are not the keys it extracts.
+			return nil
+		}
+	}
+	v = elem(v)
+	var keys []string
+	switch v.Kind() {
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			if k := elem(k); k.Kind() == reflect.String {
+				keys = append(keys, k.String())
+			}
+		}
+	case reflect.Struct:
+		for i := range v.Type().NumField() {
+			field := v.Type().Field(i)
+			inline := field.Anonymous
+			for _, t := range e.structTags {
+				if s := field.Tag.Get(t); s != "" {
+					name, opts, _ := strings.Cut(s, ",")
+					if name != "" && name != "-" && field.IsExported() {
+						keys = append(keys, name)
+					}
+					if slices.Contains(strings.Split(opts, ","), "inline") {
+						inline = true
+					}
+				}
+			}
+			if field.IsExported() {
+				keys = append(keys, e.getFieldName(field))
+			}
+			for _, f := range e.isInlineFuncs {
+				if f(field) {
+					inline = true
+				}
+			}
+			if inline {
+				keys = append(keys, e.keys(ctx, v.Field(i))...)
+			}
+		}
+	}
+	return keys
+}
+
+// suggest returns the keys which are close to key by the edit distance,
+// ordered from the closest.
+func suggest(key string, keys []string) []string {
+	target := []rune(strings.ToLower(key))
+	threshold := max(1, len(target)/3)
+	type candidate struct {
+		key      string
+		distance int
+	}
+	var candidates []candidate
+	for _, k := range keys {
+		if k == key {
+			continue
+		}
+		if d := editDistance(target, []rune(strings.ToLower(k))); d <= threshold {
+			candidates = append(candidates, candidate{key: k, distance: d})
+		}
+	}
+	slices.SortStableFunc(candidates, func(a, b candidate) int {
+		return a.distance - b.distance
+	})
+	suggestions := make([]string, len(candidates))
+	for i, c := range candidates {
+		suggestions[i] = c.key
+	}
+	if len(suggestions) == 0 {
+		return nil
+	}
+	return suggestions
+}
+
+// editDistance returns the optimal string alignment distance between a and b,
+// which counts a transposition of two adjacent characters (e.g. "emial" and
+// "email") as a single edit.
+func editDistance(a, b []rune) int {
+	d := make([][]int, len(a)+1)
+	for i := range d {
+		d[i] = make([]int, len(b)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(a)][len(b)]
+}
@@ -0,0 +1,145 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type keyListerExtractor struct {
+	keys []string
+}
+
+func (e *keyListerExtractor) ExtractByKey(_ context.Context, _ string) (any, error) {
+	return nil, ErrNotFound
+}
+
+func (e *keyListerExtractor) Keys(_ context.Context) []string {
+	return e.keys
+}
+
+func TestNotFoundError_Suggestions(t *testing.T) {
+	type user struct {
+		Email    string `json:"email"`
+		UserName string `json:"user_name"`
+		AnonymousField
+		secret string //nolint:unused // unexported fields are not available
+	}
+	tests := map[string]struct {
+		query       *Query
+		v           any
+		available   []string
+		suggestions []string
+		message     string
+	}{
+		"map": {
+			query:       New(Suggestions()).Key("user").Key("emial"),
+			v:           map[string]any{"user": map[string]any{"email": "", "name": ""}},
+			available:   []string{"email", "name"},
+			suggestions: []string{"email"},
+			message:     `".user.emial" not found`,
+		},
+		"map (message)": {
+			query:       New(SuggestionsInMessage()).Key("user").Key("emial"),
+			v:           map[string]any{"user": map[string]any{"email": "", "name": ""}},
+			available:   []string{"email", "name"},
+			suggestions: []string{"email"},
+			message:     `".user.emial" not found; did you mean "email"?`,
+		},
+		"struct": {
+			query:       New(ExtractByStructTag("json"), SuggestionsInMessage()).Key("usr_name"),
+			v:           user{},
+			available:   []string{"AnonymousField", "Email", "S", "UserName", "email", "user_name"},
+			suggestions: []string{"user_name", "UserName"},
+			message:     `".usr_name" not found; did you mean "user_name" or "UserName"?`,
+		},
+		"case differs": {
+			query:       New(SuggestionsInMessage()).Key("Name"),
+			v:           map[string]string{"name": "", "same": ""},
+			available:   []string{"name", "same"},
+			suggestions: []string{"name", "same"},
+			message:     `".Name" not found; did you mean "name" or "same"?`,
+		},
+		"no suggestions": {
+			query:     New(SuggestionsInMessage()).Key("zzz"),
+			v:         map[string]string{"name": ""},
+			available: []string{"name"},
+			message:   `".zzz" not found`,
+		},
+		"key lister": {
+			query:       New(Suggestions()).Key("fo"),
+			v:           &keyListerExtractor{keys: []string{"foo", "bar"}},
+			available:   []string{"bar", "foo"},
+			suggestions: []string{"foo"},
+			message:     `".fo" not found`,
+		},
+		"key extractor without key lister": {
+			query:   New(Suggestions()).Key("v"),
+			v:       &keyExtractor{},
+			message: `".v" not found`,
+		},
+		"wrapped by custom extract func": {
+			query: New(Suggestions(), CustomExtractFunc(func(f ExtractFunc) ExtractFunc {
+				return func(ctx context.Context, v reflect.Value) (reflect.Value, error) {
+					return f(ctx, reflect.ValueOf(&keyListerExtractor{keys: []string{"wrapped"}}))
+				}
+			})).Key("wraped"),
+			v:           struct{}{},
+			available:   []string{"wrapped"},
+			suggestions: []string{"wrapped"},
+			message:     `".wraped" not found`,
+		},
+		"without option": {
+			query:   New().Key("user").Key("emial"),
+			v:       map[string]any{"user": map[string]any{"email": "", "name": ""}},
+			message: `".user.emial" not found`,
+		},
+		"index": {
+			query:   New(Suggestions()).Key("a").Index(1),
+			v:       map[string][]int{"a": {0}},
+			message: `".a[1]" not found`,
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := test.query.Extract(context.Background(), test.v)
+			var nfe *NotFoundError
+			if !errors.As(err, &nfe) {
+				t.Fatalf("expected *NotFoundError but got %v", err)
+			}
+			if diff := cmp.Diff(test.available, nfe.Available); diff != "" {
+				t.Errorf("available keys differ: (-want +got)\n%s", diff)
+			}
+			if diff := cmp.Diff(test.suggestions, nfe.Suggestions); diff != "" {
+				t.Errorf("suggestions differ: (-want +got)\n%s", diff)
+			}
+			if got := err.Error(); got != test.message {
+				t.Errorf("expected %q but got %q", test.message, got)
+			}
+		})
+	}
+}
+
+func TestEditDistance(t *testing.T) {
+	tests := map[string]struct {
+		a, b   string
+		expect int
+	}{
+		"same":          {a: "email", b: "email", expect: 0},
+		"transposition": {a: "emial", b: "email", expect: 1},
+		"insertion":     {a: "mail", b: "email", expect: 1},
+		"substitution":  {a: "emoil", b: "email", expect: 1},
+		"empty":         {a: "", b: "abc", expect: 3},
+		"multibyte":     {a: "日本", b: "日本語", expect: 1},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := editDistance([]rune(test.a), []rune(test.b)); got != test.expect {
+				t.Errorf("expected %d but got %d", test.expect, got)
+			}
+		})
+	}
+}
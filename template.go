@@ -0,0 +1,205 @@
+package query
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/zoncoen/query-go/v2/parser"
+)
+
+// Template represents a string template with embedded queries like
+// "user {{ $.user.name }} has {{ $.items[-1].id }}". The queries are parsed
+// once by CompileTemplate, so a Template can be expanded repeatedly.
+//
+// An action "{{ query }}" is replaced with the value extracted by the query.
+// The spaces around the query are trimmed, so a key which begins or ends
+// with spaces must be written in the bracket notation like "['key ']". A
+// "}}" in a quoted key in brackets does not close the action.
+//
+// The zero value is an empty template.
+type Template struct {
+	src     string
+	texts   []string
+	actions []*templateAction
+}
+
+type templateAction struct {
+	pos   int
+	src   string
+	query *Query
+}
+
+// TemplateError represents an error of an embedded query of a template.
+type TemplateError struct {
+	// Pos is the column of the embedded query in the template. The first
+	// character of the template is at column 1.
+	Pos int
+	// Query is the source of the embedded query, or empty if the action has
+	// no query.
+	Query string
+	// Err is the error of the embedded query, e.g. parser.Errors or a
+	// *NotFoundError.
+	Err error
+}
+
+// Error implements the error interface.
+func (e *TemplateError) Error() string {
+	var errs parser.Errors
+	if errors.As(e.Err, &errs) && len(errs) > 0 {
+		return fmt.Sprintf("col %d: %s", e.Pos, errs[0].Msg)
+	}
+	return fmt.Sprintf("col %d: %s", e.Pos, e.Err)
+}
+
+// Unwrap returns the error of the embedded query.
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// CompileTemplate parses the embedded queries of tmpl with opts and returns
+// the Template. A parse error is returned as a *TemplateError which has the
+// column of the error in tmpl.
+func CompileTemplate(tmpl string, opts ...Option) (*Template, error) {
+	t := &Template{src: tmpl}
+	offset := 0
+	for {
+		i := strings.Index(tmpl[offset:], "{{")
+		if i < 0 {
+			t.texts = append(t.texts, tmpl[offset:])
+			return t, nil
+		}
+		start := offset + i
+		t.texts = append(t.texts, tmpl[offset:start])
+		end, ok := actionEnd(tmpl, start+2)
+		if !ok {
+			return nil, &TemplateError{
+				Pos: column(tmpl, start),
+				Err: errors.New(`unclosed action: "}}" not found`),
+			}
+		}
+		body := tmpl[start+2 : end]
+		src := strings.TrimSpace(body)
+		pos := column(tmpl, start+2+len(body)-len(strings.TrimLeft(body, " \t\r\n")))
+		if src == "" {
+			return nil, &TemplateError{
+				Pos: column(tmpl, start),
+				Err: errors.New("empty action"),
+			}
+		}
+		q, err := ParseString(src, opts...)
+		if err != nil {
+			e := &TemplateError{Pos: pos, Query: src, Err: err}
+			var errs parser.Errors
+			if errors.As(err, &errs) && len(errs) > 0 {
+				e.Pos += errs[0].Pos - 1
+			}
+			return nil, e
+		}
+		t.actions = append(t.actions, &templateAction{
+			pos:   pos,
+			src:   src,
+			query: q,
+		})
+		offset = end + 2
+	}
+}
+
+// actionEnd returns the offset of "}}" which closes the action beginning
+// at offset i, skipping quoted keys in brackets.
+func actionEnd(s string, i int) (int, bool) {
+	var quote byte
+	for ; i < len(s); i++ {
+		ch := s[i]
+		if quote != 0 {
+			switch ch {
+			case '\\':
+				i++
+			case quote:
+				quote = 0
+			}
+			continue
+		}
+		switch {
+		case (ch == '\'' || ch == '"') && i > 0 && s[i-1] == '[':
+			quote = ch
+		case ch == '}' && strings.HasPrefix(s[i:], "}}"):
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// column returns the column of the byte offset i of s.
+func column(s string, i int) int {
+	return utf8.RuneCountInString(s[:i]) + 1
+}
+
+// Expand extracts the values by the embedded queries from target and
+// returns the template in which the actions are replaced with the values
+// formatted by "%v". An extraction error is returned as a *TemplateError
+// which has the column of the failing query.
+func (t *Template) Expand(ctx context.Context, target any) (string, error) {
+	return t.expand(ctx, target, func(v any) (string, error) {
+		return fmt.Sprint(v), nil
+	})
+}
+
+// ExpandJSON is like Expand but formats the values as JSON, e.g. a string is
+// quoted and a map is an object.
+func (t *Template) ExpandJSON(ctx context.Context, target any) (string, error) {
+	return t.expand(ctx, target, func(v any) (string, error) {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return "", err
+		}
+		return string(b), nil
+	})
+}
+
+func (t *Template) expand(ctx context.Context, target any, format func(any) (string, error)) (string, error) {
+	var b strings.Builder
+	for i, a := range t.actions {
+		b.WriteString(t.texts[i])
+		v, err := a.query.Extract(ctx, target)
+		if err == nil {
+			var s string
+			if s, err = format(v); err == nil {
+				b.WriteString(s)
+				continue
+			}
+		}
+		return "", &TemplateError{Pos: a.pos, Query: a.src, Err: err}
+	}
+	if len(t.texts) > len(t.actions) {
+		b.WriteString(t.texts[len(t.texts)-1])
+	}
+	return b.String(), nil
+}
+
+// String returns the source of t.
+func (t *Template) String() string {
+	return t.src
+}
+
+// Expand compiles tmpl by CompileTemplate with opts and expands it by
+// Template.Expand. Use CompileTemplate to expand a template repeatedly.
+func Expand(ctx context.Context, tmpl string, target any, opts ...Option) (string, error) {
+	t, err := CompileTemplate(tmpl, opts...)
+	if err != nil {
+		return "", err
+	}
+	return t.Expand(ctx, target)
+}
+
+// ExpandJSON is like Expand but expands the template by Template.ExpandJSON.
+func ExpandJSON(ctx context.Context, tmpl string, target any, opts ...Option) (string, error) {
+	t, err := CompileTemplate(tmpl, opts...)
+	if err != nil {
+		return "", err
+	}
+	return t.ExpandJSON(ctx, target)
+}
@@ -0,0 +1,162 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/zoncoen/query-go/v2/parser"
+)
+
+func TestTemplate_Expand(t *testing.T) {
+	target := map[string]any{
+		"user": map[string]any{
+			"name": "alice",
+			"}}":   "braces",
+		},
+		"items": []any{
+			map[string]any{"id": 1},
+			map[string]any{"id": 2},
+		},
+	}
+	t.Run("success", func(t *testing.T) {
+		tests := map[string]struct {
+			tmpl       string
+			opts       []Option
+			expect     string
+			expectJSON string
+		}{
+			"no action": {
+				tmpl:       "plain text",
+				expect:     "plain text",
+				expectJSON: "plain text",
+			},
+			"actions": {
+				tmpl:       "user {{ $.user.name }} has {{$.items[-1].id}}",
+				expect:     "user alice has 2",
+				expectJSON: `user "alice" has 2`,
+			},
+			"braces in quoted key": {
+				tmpl:       "{{ $.user['}}'] }}!",
+				expect:     "braces!",
+				expectJSON: `"braces"!`,
+			},
+			"object": {
+				tmpl:       "{{ items[0] }}",
+				expect:     "map[id:1]",
+				expectJSON: `{"id":1}`,
+			},
+			"options": {
+				tmpl:       "{{ USER.NAME }}",
+				opts:       []Option{CaseInsensitive()},
+				expect:     "alice",
+				expectJSON: `"alice"`,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				tmpl, err := CompileTemplate(test.tmpl, test.opts...)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				got, err := tmpl.Expand(context.Background(), target)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expect {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+				got, err = tmpl.ExpandJSON(context.Background(), target)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expectJSON {
+					t.Errorf("expect %q but got %q", test.expectJSON, got)
+				}
+				got, err = Expand(context.Background(), test.tmpl, target, test.opts...)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expect {
+					t.Errorf("expect %q but got %q", test.expect, got)
+				}
+				got, err = ExpandJSON(context.Background(), test.tmpl, target, test.opts...)
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+				if got != test.expectJSON {
+					t.Errorf("expect %q but got %q", test.expectJSON, got)
+				}
+			})
+		}
+	})
+	t.Run("zero value", func(t *testing.T) {
+		var tmpl Template
+		got, err := tmpl.Expand(context.Background(), target)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != "" {
+			t.Errorf("expect empty string but got %q", got)
+		}
+		got, err = tmpl.ExpandJSON(context.Background(), target)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != "" {
+			t.Errorf("expect empty string but got %q", got)
+		}
+	})
+	t.Run("failure", func(t *testing.T) {
+		tests := map[string]struct {
+			tmpl          string
+			expectPos     int
+			expectMessage string
+			expectErr     error
+		}{
+			"unclosed action": {
+				tmpl:          "abc {{ $.user",
+				expectPos:     5,
+				expectMessage: `col 5: unclosed action: "}}" not found`,
+			},
+			"empty action": {
+				tmpl:          "é {{ }}",
+				expectPos:     3,
+				expectMessage: "col 3: empty action",
+			},
+			"parse error": {
+				tmpl:          "é {{ $.items[a] }}",
+				expectPos:     14,
+				expectMessage: `col 14: illegal character 'a' in brackets`,
+				expectErr:     parser.ErrIllegalCharacter,
+			},
+			"not found": {
+				tmpl:          "user {{ $.user.name }} has {{ $.items[2].id }}",
+				expectPos:     31,
+				expectMessage: `col 31: "$.items[2].id" not found`,
+				expectErr:     ErrNotFound,
+			},
+		}
+		for name, test := range tests {
+			t.Run(name, func(t *testing.T) {
+				_, err := Expand(context.Background(), test.tmpl, target)
+				var te *TemplateError
+				if !errors.As(err, &te) {
+					t.Fatalf("expected *TemplateError but got %v", err)
+				}
+				if te.Pos != test.expectPos {
+					t.Errorf("expect %d but got %d", test.expectPos, te.Pos)
+				}
+				if got := err.Error(); got != test.expectMessage {
+					t.Errorf("expect %q but got %q", test.expectMessage, got)
+				}
+				if test.expectErr != nil && !errors.Is(err, test.expectErr) {
+					t.Errorf("expected %v but got %v", test.expectErr, err)
+				}
+				if _, err := ExpandJSON(context.Background(), test.tmpl, target); !errors.As(err, &te) || te.Pos != test.expectPos {
+					t.Errorf("expect *TemplateError at %d but got %v", test.expectPos, err)
+				}
+			})
+		}
+	})
+}
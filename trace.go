@@ -0,0 +1,137 @@
+package query
+
+import (
+	"context"
+	"reflect"
+	"time"
+)
+
+// Tracer is the interface to observe each step of Query.Extract. Set it by
+// the WithTracer option.
+type Tracer interface {
+	// StepStart is called before the extractor of the step is applied.
+	StepStart(ctx context.Context, step Step)
+	// StepEnd is called after the extractor of the step is applied, even if
+	// it failed. If it panicked without the RecoverPanic option, StepEnd is
+	// called with a *PanicError before the panic continues.
+	StepEnd(ctx context.Context, step Step, result StepResult)
+}
+
+// Step represents a step of an extraction.
+type Step struct {
+	// Index is the zero-based index of the extractor in the query.
+	Index int
+	// Extractor is the extractor of the step.
+	Extractor Extractor
+	// InputType is the dynamic type of the value which the extractor is
+	// applied to, or nil if the value is nil.
+	InputType reflect.Type
+}
+
+// StepResult represents the result of a step of an extraction.
+type StepResult struct {
+	// Resolution is how Key or Index resolved the value.
+	Resolution Resolution
+	// OutputType is the dynamic type of the extracted value, or nil if the
+	// value is nil or the step failed.
+	OutputType reflect.Type
+	// Duration is the time taken by the step.
+	Duration time.Duration
+	// Err is the error of the step.
+	Err error
+}
+
+// Resolution represents how Key or Index resolved the value.
+type Resolution int
+
+const (
+	// ResolutionNone means the value was not resolved, or it was resolved
+	// by an extractor other than Key and Index, or by a Key or an Index
+	// created by a query without the WithTracer option.
+	ResolutionNone Resolution = iota
+	// ResolutionKeyExtractor means the value was resolved by KeyExtractor.
+	ResolutionKeyExtractor
+	// ResolutionIndexExtractor means the value was resolved by
+	// IndexExtractor.
+	ResolutionIndexExtractor
+	// ResolutionMapKey means the value was resolved by a map key.
+	ResolutionMapKey
+	// ResolutionStructField means the value was resolved by a struct field
+	// name.
+	ResolutionStructField
+	// ResolutionStructTag means the value was resolved by a struct tag name.
+	ResolutionStructTag
+	// ResolutionInlineField means the value was resolved in an inline
+	// struct field.
+	ResolutionInlineField
+	// ResolutionSequenceIndex means the value was resolved by an index of
+	// a slice or an array.
+	ResolutionSequenceIndex
+)
+
+// String returns r as string.
+func (r Resolution) String() string {
+	switch r {
+	case ResolutionKeyExtractor:
+		return "KeyExtractor"
+	case ResolutionIndexExtractor:
+		return "IndexExtractor"
+	case ResolutionMapKey:
+		return "map key"
+	case ResolutionStructField:
+		return "struct field"
+	case ResolutionStructTag:
+		return "struct tag"
+	case ResolutionInlineField:
+		return "inline field"
+	case ResolutionSequenceIndex:
+		return "sequence index"
+	}
+	return "none"
+}
+
+// stepTraceKey is the context key of the *stepTrace of the current step.
+type stepTraceKey struct{}
+
+// stepTrace records the resolution of the current step. It is stored in the
+// context only if the query has a tracer, so the extractors record nothing
+// by default.
+type stepTrace struct {
+	resolution Resolution
+}
+
+// traceResolution records r as the resolution of the current step.
+// The extractors call it only if they are created by a query which has a
+// tracer, so that the context is not looked up by default.
+func traceResolution(ctx context.Context, r Resolution) {
+	if t, ok := ctx.Value(stepTraceKey{}).(*stepTrace); ok {
+		t.resolution = r
+	}
+}
+
+// traceResolution records r as the resolution of the current step if e is
+// traced.
+func (e *Key) traceResolution(ctx context.Context, r Resolution) {
+	if e.trace {
+		traceResolution(ctx, r)
+	}
+}
+
+// traceResolution records r as the resolution of the current step if e is
+// traced.
+func (e *Index) traceResolution(ctx context.Context, r Resolution) {
+	if e.trace {
+		traceResolution(ctx, r)
+	}
+}
+
+// valueType returns the dynamic type of v, or nil if v is nil.
+func valueType(v reflect.Value) reflect.Type {
+	for v.IsValid() && v.Kind() == reflect.Interface {
+		v = v.Elem()
+	}
+	if !v.IsValid() {
+		return nil
+	}
+	return v.Type()
+}
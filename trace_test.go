@@ -0,0 +1,199 @@
+package query
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type traceRecord struct {
+	index      int
+	extractor  string
+	inputType  reflect.Type
+	resolution Resolution
+	outputType reflect.Type
+	err        bool
+}
+
+type recordingTracer struct {
+	started int
+	records []traceRecord
+}
+
+func (r *recordingTracer) StepStart(_ context.Context, _ Step) {
+	r.started++
+}
+
+func (r *recordingTracer) StepEnd(_ context.Context, step Step, res StepResult) {
+	r.records = append(r.records, traceRecord{
+		index:      step.Index,
+		extractor:  step.Extractor.String(),
+		inputType:  step.InputType,
+		resolution: res.Resolution,
+		outputType: res.OutputType,
+		err:        res.Err != nil,
+	})
+}
+
+func TestWithTracer(t *testing.T) {
+	target := map[string]any{
+		"tags": testTags{
+			FooBar:         "foo",
+			AnonymousField: AnonymousField{S: "s"},
+			M:              map[string]string{"m": "inline"},
+		},
+		"list": []int{1, 2},
+		"ke":   &keyExtractor{v: "v"},
+	}
+	var (
+		mapType  = reflect.TypeOf(target)
+		tagsType = reflect.TypeOf(testTags{})
+		strType  = reflect.TypeOf("")
+	)
+	tests := map[string]struct {
+		query  string
+		opts   []Option
+		expect []traceRecord
+	}{
+		"struct field": {
+			query: "tags.FooBar",
+			expect: []traceRecord{
+				{index: 0, extractor: ".tags", inputType: mapType, resolution: ResolutionMapKey, outputType: tagsType},
+				{index: 1, extractor: ".FooBar", inputType: tagsType, resolution: ResolutionStructField, outputType: strType},
+			},
+		},
+		"struct tag": {
+			query: "tags.foo_bar",
+			opts:  []Option{ExtractByStructTag("json")},
+			expect: []traceRecord{
+				{index: 0, extractor: ".tags", inputType: mapType, resolution: ResolutionMapKey, outputType: tagsType},
+				{index: 1, extractor: ".foo_bar", inputType: tagsType, resolution: ResolutionStructTag, outputType: strType},
+			},
+		},
+		"inline field": {
+			query: "tags.S",
+			expect: []traceRecord{
+				{index: 0, extractor: ".tags", inputType: mapType, resolution: ResolutionMapKey, outputType: tagsType},
+				{index: 1, extractor: ".S", inputType: tagsType, resolution: ResolutionInlineField, outputType: strType},
+			},
+		},
+		"sequence index": {
+			query: "list[1]",
+			expect: []traceRecord{
+				{index: 0, extractor: ".list", inputType: mapType, resolution: ResolutionMapKey, outputType: reflect.TypeOf([]int{})},
+				{index: 1, extractor: "[1]", inputType: reflect.TypeOf([]int{}), resolution: ResolutionSequenceIndex, outputType: reflect.TypeOf(0)},
+			},
+		},
+		"KeyExtractor": {
+			query: "ke.k",
+			expect: []traceRecord{
+				{index: 0, extractor: ".ke", inputType: mapType, resolution: ResolutionMapKey, outputType: reflect.TypeOf(&keyExtractor{})},
+				{index: 1, extractor: ".k", inputType: reflect.TypeOf(&keyExtractor{}), resolution: ResolutionKeyExtractor, outputType: strType},
+			},
+		},
+		"failure": {
+			query: "list.x.y",
+			expect: []traceRecord{
+				{index: 0, extractor: ".list", inputType: mapType, resolution: ResolutionMapKey, outputType: reflect.TypeOf([]int{})},
+				{index: 1, extractor: ".x", inputType: reflect.TypeOf([]int{}), err: true},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			tracer := &recordingTracer{}
+			q, err := ParseString(test.query, append(test.opts, WithTracer(tracer))...)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			_, _ = q.Extract(context.Background(), target)
+			if tracer.started != len(test.expect) {
+				t.Errorf("expect %d steps but got %d", len(test.expect), tracer.started)
+			}
+			opts := []cmp.Option{
+				cmp.AllowUnexported(traceRecord{}),
+				cmp.Comparer(func(x, y reflect.Type) bool { return x == y }),
+			}
+			if diff := cmp.Diff(test.expect, tracer.records, opts...); diff != "" {
+				t.Errorf("differs: (-want +got)\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithTracer_UntracedExtractors(t *testing.T) {
+	tracer := &recordingTracer{}
+	q := New(WithTracer(tracer)).Append(New().Key("a").Extractors()...)
+	if _, err := q.Extract(context.Background(), map[string]int{"a": 1}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	expect := []traceRecord{
+		{index: 0, extractor: ".a", inputType: reflect.TypeOf(map[string]int{}), resolution: ResolutionNone, outputType: reflect.TypeOf(0)},
+	}
+	opts := []cmp.Option{
+		cmp.AllowUnexported(traceRecord{}),
+		cmp.Comparer(func(x, y reflect.Type) bool { return x == y }),
+	}
+	if diff := cmp.Diff(expect, tracer.records, opts...); diff != "" {
+		t.Errorf("differs: (-want +got)\n%s", diff)
+	}
+}
+
+func TestWithTracer_RecoverPanic(t *testing.T) {
+	var got StepResult
+	tracer := &funcTracer{end: func(_ Step, res StepResult) { got = res }}
+	_, err := New(WithTracer(tracer), RecoverPanic()).Append(extractorFunc(func(context.Context, reflect.Value) (reflect.Value, error) {
+		panic("broken")
+	})).Extract(context.Background(), 1)
+	var pe *PanicError
+	if !errors.As(got.Err, &pe) {
+		t.Fatalf("expected *PanicError in the trace but got %v", got.Err)
+	}
+	if got.Err != err {
+		t.Errorf("expected the returned error %v but got %v", err, got.Err)
+	}
+}
+
+func TestWithTracer_Panic(t *testing.T) {
+	var (
+		ended bool
+		got   StepResult
+	)
+	tracer := &funcTracer{end: func(_ Step, res StepResult) { ended, got = true, res }}
+	func() {
+		defer func() {
+			if r := recover(); r != "broken" {
+				t.Errorf("expected the panic to continue but got %v", r)
+			}
+		}()
+		_, _ = New(WithTracer(tracer)).Append(extractorFunc(func(context.Context, reflect.Value) (reflect.Value, error) {
+			panic("broken")
+		})).Extract(context.Background(), 1)
+	}()
+	if !ended {
+		t.Fatal("StepEnd is not called")
+	}
+	var pe *PanicError
+	if !errors.As(got.Err, &pe) {
+		t.Fatalf("expected *PanicError in the trace but got %v", got.Err)
+	}
+	if pe.Value != "broken" {
+		t.Errorf("expected the panic value but got %v", pe.Value)
+	}
+	if got.OutputType != nil {
+		t.Errorf("expected no output type but got %v", got.OutputType)
+	}
+}
+
+type funcTracer struct {
+	end func(Step, StepResult)
+}
+
+func (f *funcTracer) StepStart(context.Context, Step) {}
+
+func (f *funcTracer) StepEnd(_ context.Context, step Step, res StepResult) {
+	f.end(step, res)
+}